@@ -0,0 +1,235 @@
+// Package bench runs seeded trials of several planners on the same
+// problem and compares the results.
+package bench
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/bz-2021/rrt_star/rrt"
+	"github.com/bz-2021/rrt_star/stats"
+)
+
+// Planner is a named planner configuration. New is called once per trial
+// and must return a fresh planner. Params describe the configuration in
+// exported results.
+type Planner struct {
+	Name   string
+	New    func() (*rrt.RRT, error)
+	Params []Param
+}
+
+// Runner runs Trials trials of every planner. Trial i uses the seed
+// Seed+i for all planners, so they are compared on the same random
+// sequences.
+//
+// Trials run concurrently on Workers goroutines. Every trial builds its
+// own planner and depends only on its seed, so the results do not depend
+// on the number of workers; only the measured wall times do, because the
+// trials compete for the CPU. This no longer holds for trials that stop on
+// wall-clock time, through Timeout or the planner's TimeBudget: with more
+// workers per CPU they get less done before the time runs out.
+type Runner struct {
+	Scenario string // 场景名称，写入导出的结果
+	Planners []Planner
+	Trials   int
+	Seed     int64
+	Timeout  time.Duration // 单次试验的时间上限，为 0 时不限制
+	Workers  int           // 并发执行试验的数量，为 0 时使用 GOMAXPROCS
+
+	// TraceEvery 大于 0 时每次试验每隔这么多次迭代记录一次搜索进度，见 rrt.RRT.TraceEvery
+	TraceEvery int
+}
+
+// Trial is the outcome of one run of one planner.
+type Trial struct {
+	Planner string
+	Index   int   // 试验序号，从 0 开始
+	Seed    int64 // 本次试验使用的种子
+	Result  rrt.Result
+	Err     error // rrt.ErrTimeout 表示超时
+}
+
+// Comparison is the aggregate of all trials of one planner.
+type Comparison struct {
+	Planner   string
+	Params    []Param
+	Aggregate stats.Aggregate
+	Timeouts  int
+}
+
+// Pair compares two planners on the path length of their successful runs
+// and on the planning time of all runs.
+type Pair struct {
+	A, B     string
+	Cost     stats.TwoSample
+	WallTime stats.TwoSample // 单位为秒
+}
+
+// Report holds every trial and the per-planner aggregates, both in the
+// order of Runner.Planners, and the pairwise comparisons of all planners.
+type Report struct {
+	Scenario    string
+	Trials      []Trial
+	Comparisons []Comparison
+	Pairs       []Pair
+}
+
+// Run executes the trials. If ctx is canceled or a planner cannot be
+// built, the report of the trials finished so far is returned together
+// with the error (rrt.ErrCanceled for a canceled ctx).
+func (r *Runner) Run(ctx context.Context) (*Report, error) {
+	workers := r.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// 结果按 (算法, 试验序号) 写入固定位置，与执行顺序无关
+	type job struct{ planner, index int }
+	trials := make([][]Trial, len(r.Planners))
+	finished := make([][]bool, len(r.Planners))
+	for i := range trials {
+		trials[i] = make([]Trial, r.Trials)
+		finished[i] = make([]bool, r.Trials)
+	}
+
+	var mu sync.Mutex
+	var runErr error
+	jobs := make(chan job)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				t, err := r.trial(ctx, r.Planners[j.planner], j.index)
+				if err != nil {
+					mu.Lock()
+					if runErr == nil {
+						runErr = err
+					}
+					mu.Unlock()
+					cancel()
+					continue
+				}
+				trials[j.planner][j.index] = t
+				finished[j.planner][j.index] = true
+			}
+		}()
+	}
+
+feed:
+	for p := range r.Planners {
+		for i := 0; i < r.Trials; i++ {
+			select {
+			case jobs <- job{p, i}:
+			case <-ctx.Done():
+				break feed
+			}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	report := &Report{Scenario: r.Scenario}
+	for p, planner := range r.Planners {
+		var done []Trial
+		for i, t := range trials[p] {
+			if finished[p][i] {
+				done = append(done, t)
+			}
+		}
+		report.add(planner, done)
+	}
+	report.compare()
+	if runErr == nil && len(report.Trials) < len(r.Planners)*r.Trials {
+		runErr = rrt.ErrCanceled
+	}
+	return report, runErr
+}
+
+// trial runs trial i of planner p. Only errors that end the whole run are
+// returned; a timeout is recorded in the trial.
+func (r *Runner) trial(ctx context.Context, p Planner, i int) (Trial, error) {
+	if err := ctx.Err(); err != nil {
+		return Trial{}, rrt.ErrCanceled
+	}
+	planner, err := p.New()
+	if err != nil {
+		return Trial{}, err
+	}
+	planner.Seed = r.Seed + int64(i)
+	planner.TraceEvery = r.TraceEvery
+
+	var trialCtx context.Context
+	var cancel context.CancelFunc
+	if r.Timeout > 0 {
+		trialCtx, cancel = context.WithTimeout(ctx, r.Timeout)
+	} else {
+		trialCtx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	result, err := planner.PlanContext(trialCtx)
+	if errors.Is(err, rrt.ErrCanceled) {
+		return Trial{}, err
+	}
+	return Trial{
+		Planner: p.Name,
+		Index:   i,
+		Seed:    planner.Seed,
+		Result:  result,
+		Err:     err,
+	}, nil
+}
+
+// add appends the trials of a planner and their aggregate.
+func (rep *Report) add(p Planner, trials []Trial) {
+	if len(trials) == 0 {
+		return
+	}
+
+	results := make([]rrt.Result, len(trials))
+	timeouts := 0
+	for i, t := range trials {
+		results[i] = t.Result
+		if errors.Is(t.Err, rrt.ErrTimeout) {
+			timeouts++
+		}
+	}
+	rep.Trials = append(rep.Trials, trials...)
+	rep.Comparisons = append(rep.Comparisons, Comparison{
+		Planner:   p.Name,
+		Params:    p.Params,
+		Aggregate: stats.Collect(results),
+		Timeouts:  timeouts,
+	})
+}
+
+// compare fills Pairs with the tests between every two planners.
+func (rep *Report) compare() {
+	cost := map[string][]float64{}
+	wallTime := map[string][]float64{}
+	for _, t := range rep.Trials {
+		if t.Result.Success {
+			cost[t.Planner] = append(cost[t.Planner], t.Result.Cost)
+		}
+		wallTime[t.Planner] = append(wallTime[t.Planner], t.Result.WallTime.Seconds())
+	}
+
+	for i, a := range rep.Comparisons {
+		for _, b := range rep.Comparisons[i+1:] {
+			rep.Pairs = append(rep.Pairs, Pair{
+				A:        a.Planner,
+				B:        b.Planner,
+				Cost:     stats.Compare(cost[a.Planner], cost[b.Planner]),
+				WallTime: stats.Compare(wallTime[a.Planner], wallTime[b.Planner]),
+			})
+		}
+	}
+}
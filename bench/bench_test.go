@@ -0,0 +1,75 @@
+package bench
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/bz-2021/rrt_star/rrt"
+)
+
+// planner returns a planner on the map of scenarios/five_rects.yaml that
+// steers with the improved APF if apf is set.
+func planner(name string, mode rrt.Mode, apf bool) Planner {
+	return Planner{Name: name, New: func() (*rrt.RRT, error) {
+		obstacles := []rrt.Obstacle{
+			rrt.NewRect(150, 150, 150, 150),
+			rrt.NewRect(600, 200, 100, 100),
+			rrt.NewRect(200, 600, 100, 100),
+			rrt.NewRect(700, 700, 150, 150),
+			rrt.NewRect(400, 400, 200, 200),
+		}
+		r := rrt.NewRRT(rrt.Point{X: 0, Y: 0}, rrt.Point{X: 999, Y: 999}, 20, 20, 800, 1000, 1000, obstacles, 10)
+		r.Mode = mode
+		if apf {
+			r.Extender = rrt.NewImprovedAPF(1, 0.5, 50)
+		}
+		return r, nil
+	}}
+}
+
+func TestRunDoesNotDependOnWorkers(t *testing.T) {
+	run := func(workers int) []Trial {
+		runner := &Runner{
+			Planners: []Planner{
+				planner("rrt", rrt.ModeRRT, false),
+				planner("rrtstar+improved-apf", rrt.ModeRRTStar, true),
+				planner("connect", rrt.ModeConnect, false),
+			},
+			Trials:     6,
+			Seed:       1,
+			Workers:    workers,
+			TraceEvery: 50,
+		}
+		rep, err := runner.Run(context.Background())
+		if err != nil {
+			t.Fatalf("%d workers: %v", workers, err)
+		}
+
+		// 只有耗时与工作协程数有关
+		for i := range rep.Trials {
+			res := &rep.Trials[i].Result
+			res.WallTime = 0
+			trace := append([]rrt.TraceSample(nil), res.Trace...)
+			for k := range trace {
+				trace[k].Elapsed = 0
+			}
+			res.Trace = trace
+		}
+		return rep.Trials
+	}
+
+	one, eight := run(1), run(8)
+	if len(one) != 3*6 || len(eight) != len(one) {
+		t.Fatalf("got %d and %d trials, want %d", len(one), len(eight), 3*6)
+	}
+	for i := range one {
+		if len(one[i].Result.Trace) == 0 {
+			t.Errorf("%s trial %d: no trace recorded", one[i].Planner, one[i].Index)
+		}
+		if !reflect.DeepEqual(one[i], eight[i]) {
+			t.Errorf("%s trial %d differs between 1 and 8 workers:\n\t%+v\n\t%+v",
+				one[i].Planner, one[i].Index, one[i].Result, eight[i].Result)
+		}
+	}
+}
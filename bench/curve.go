@@ -0,0 +1,172 @@
+package bench
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/bz-2021/rrt_star/rrt"
+	"github.com/bz-2021/rrt_star/stats"
+)
+
+// Axis is the x axis of a convergence curve.
+type Axis int
+
+const (
+	AxisIteration Axis = iota // 迭代次数
+	AxisTime                  // 从开始规划起的耗时，单位为毫秒
+)
+
+var axisNames = map[Axis]string{
+	AxisIteration: "iteration",
+	AxisTime:      "time",
+}
+
+// String returns the name of the axis.
+func (a Axis) String() string {
+	if name, ok := axisNames[a]; ok {
+		return name
+	}
+	return fmt.Sprintf("Axis(%d)", int(a))
+}
+
+// ParseAxis parses an axis name as returned by Axis.String.
+func ParseAxis(name string) (Axis, error) {
+	for a, n := range axisNames {
+		if n == name {
+			return a, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown axis %q, expected iteration or time", name)
+}
+
+// Band is the range between two quantiles of the best cost across trials.
+type Band struct {
+	Level     float64 // 覆盖的比例，如 0.5 表示 25% 到 75% 分位数
+	Low, High []float64
+}
+
+// Curve is the best path cost of one planner over the search, aggregated
+// across its trials at common points X.
+//
+// At every point a trial that has not found a path yet counts as +Inf, so
+// the curve never rises when a late trial finds a long path. Mean is NaN
+// until every trial has a path, and a band bound is NaN while its quantile
+// still falls on trials without one. A trial that has ended keeps its
+// final cost. On AxisTime the elapsed times
+// include the time the trial waited for the CPU, so run the benchmark with
+// a single worker when the time axis matters.
+type Curve struct {
+	Planner string
+	Trials  int       // 记录了搜索进度的试验数
+	X       []float64 // 迭代次数，或以毫秒计的耗时
+	Solved  []int     // 在 X 处已找到路径的试验数
+	Mean    []float64
+	Bands   []Band
+}
+
+// Curves aggregates the traces recorded with Runner.TraceEvery into one
+// curve per planner. All curves share the same points: n values evenly
+// spaced from 0 to the end of the longest trial. levels are the coverages
+// of the quantile bands, e.g. 0.5 and 0.9. Planners without traces are
+// left out.
+func (rep *Report) Curves(axis Axis, n int, levels []float64) []Curve {
+	traces := map[string][][]rrt.TraceSample{}
+	end := 0.0
+	for _, t := range rep.Trials {
+		if len(t.Result.Trace) == 0 {
+			continue
+		}
+		traces[t.Planner] = append(traces[t.Planner], t.Result.Trace)
+		end = math.Max(end, position(axis, t.Result.Trace[len(t.Result.Trace)-1]))
+	}
+	if n < 2 {
+		n = 2
+	}
+
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = end * float64(i) / float64(n-1)
+	}
+
+	var curves []Curve
+	for _, c := range rep.Comparisons {
+		if len(traces[c.Planner]) == 0 {
+			continue
+		}
+		curves = append(curves, curve(c.Planner, traces[c.Planner], axis, x, levels))
+	}
+	return curves
+}
+
+// curve aggregates the traces of one planner at the points x.
+func curve(planner string, traces [][]rrt.TraceSample, axis Axis, x []float64, levels []float64) Curve {
+	c := Curve{
+		Planner: planner,
+		Trials:  len(traces),
+		X:       x,
+		Solved:  make([]int, len(x)),
+		Mean:    make([]float64, len(x)),
+	}
+	for _, level := range levels {
+		c.Bands = append(c.Bands, Band{
+			Level: level,
+			Low:   make([]float64, len(x)),
+			High:  make([]float64, len(x)),
+		})
+	}
+
+	for i, xi := range x {
+		var costs []float64
+		for _, trace := range traces {
+			if cost := costAt(axis, trace, xi); !math.IsInf(cost, 1) {
+				costs = append(costs, cost)
+			}
+		}
+		sort.Float64s(costs)
+		c.Solved[i] = len(costs)
+
+		c.Mean[i] = math.NaN()
+		if len(costs) == len(traces) {
+			c.Mean[i] = stats.Mean(costs)
+		}
+		for _, b := range c.Bands {
+			b.Low[i] = quantile(costs, len(traces), (1-b.Level)/2)
+			b.High[i] = quantile(costs, len(traces), (1+b.Level)/2)
+		}
+	}
+	return c
+}
+
+// quantile returns the q quantile of n costs of which the sorted finite
+// ones are given and the rest are +Inf, or NaN if it is not finite. It
+// interpolates like stats.Quantile.
+func quantile(sorted []float64, n int, q float64) float64 {
+	h := q * float64(n-1)
+	lo := int(math.Floor(h))
+	if int(math.Ceil(h)) >= len(sorted) {
+		return math.NaN()
+	}
+	if lo == len(sorted)-1 {
+		return sorted[lo]
+	}
+	return sorted[lo] + (h-float64(lo))*(sorted[lo+1]-sorted[lo])
+}
+
+// costAt returns the best cost of a trace at x: the cost of the last
+// sample at or before x, or the first sample's if x precedes it.
+func costAt(axis Axis, trace []rrt.TraceSample, x float64) float64 {
+	i := sort.Search(len(trace), func(i int) bool { return position(axis, trace[i]) > x })
+	if i == 0 {
+		return trace[0].Cost
+	}
+	return trace[i-1].Cost
+}
+
+// position returns the coordinate of a sample on the axis.
+func position(axis Axis, s rrt.TraceSample) float64 {
+	if axis == AxisTime {
+		return 1000 * s.Elapsed.Seconds()
+	}
+	return float64(s.Iteration)
+}
@@ -0,0 +1,330 @@
+package bench
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bz-2021/rrt_star/rrt"
+)
+
+// Param is a named planner setting recorded with every exported row, so
+// archived results can be told apart and diffed.
+type Param struct {
+	Name  string
+	Value any // float64、int、bool 或 string
+}
+
+// Format is an export format of a Report.
+type Format int
+
+const (
+	FormatCSV       Format = iota // 逗号分隔，第一行为表头
+	FormatJSONLines               // 每行一个 JSON 对象
+	FormatMarkdown                // Markdown 表格
+	FormatLaTeX                   // LaTeX tabular 环境
+)
+
+var formatNames = map[Format]string{
+	FormatCSV:       "csv",
+	FormatJSONLines: "jsonl",
+	FormatMarkdown:  "markdown",
+	FormatLaTeX:     "latex",
+}
+
+// String returns the name of the format.
+func (f Format) String() string {
+	if name, ok := formatNames[f]; ok {
+		return name
+	}
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+// FormatOf picks the export format from the extension of a file name:
+// .csv, .jsonl (or .ndjson), .md and .tex.
+func FormatOf(filename string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".jsonl", ".ndjson":
+		return FormatJSONLines, nil
+	case ".md":
+		return FormatMarkdown, nil
+	case ".tex":
+		return FormatLaTeX, nil
+	}
+	return 0, fmt.Errorf("unknown export format of %q, expected .csv, .jsonl, .md or .tex", filename)
+}
+
+// table is a list of rows with named columns. A nil cell is a missing
+// value: empty in CSV, null in JSON and "-" in the text tables.
+type table struct {
+	header []string
+	rows   [][]any
+}
+
+// WriteTrials writes one row per trial: the scenario, the planner, the
+// trial index and seed, the measured result and the planner parameters.
+func (rep *Report) WriteTrials(w io.Writer, f Format) error {
+	t := table{header: []string{
+		"scenario", "algorithm", "trial", "seed", "success", "error",
+		"cost", "waypoints", "iterations", "nodes", "collision_checks", "escapes", "wall_time_ms",
+	}}
+	for _, tr := range rep.Trials {
+		res := tr.Result
+		var cost, errText any
+		if res.Success {
+			cost = res.Cost
+		}
+		if tr.Err != nil {
+			errText = tr.Err.Error()
+		}
+		t.rows = append(t.rows, []any{
+			rep.Scenario, tr.Planner, tr.Index, tr.Seed, res.Success, errText,
+			cost, res.Waypoints, res.Iterations, res.Nodes, res.CollisionChecks, res.Escapes,
+			milliseconds(res),
+		})
+	}
+	return rep.withParams(t, 1).write(w, f)
+}
+
+// WriteSummary writes one row per planner with the aggregated results,
+// the range of seeds used and the planner parameters.
+func (rep *Report) WriteSummary(w io.Writer, f Format) error {
+	t := table{header: []string{
+		"scenario", "algorithm", "first_seed", "last_seed", "runs", "successes", "success_rate", "timeouts",
+		"cost_mean", "cost_std", "cost_median", "cost_ci_low", "cost_ci_high",
+		"wall_time_ms_mean", "wall_time_ms_std", "wall_time_ms_median",
+		"nodes_mean", "nodes_std", "iterations_mean", "collision_checks_mean",
+	}}
+	for _, c := range rep.Comparisons {
+		first, last := rep.seedRange(c.Planner)
+		a := c.Aggregate
+		cost := []any{nil, nil, nil, nil, nil}
+		if a.Cost.N > 0 {
+			cost = []any{a.Cost.Mean, a.Cost.Std, a.Cost.Median, a.Cost.CILow, a.Cost.CIHigh}
+		}
+		row := []any{rep.Scenario, c.Planner, first, last, a.Runs, a.Successes, a.SuccessRate, c.Timeouts}
+		row = append(row, cost...)
+		row = append(row,
+			1000*a.WallTime.Mean, 1000*a.WallTime.Std, 1000*a.WallTime.Median,
+			a.Nodes.Mean, a.Nodes.Std, a.Iterations.Mean, a.CollisionChecks.Mean)
+		t.rows = append(t.rows, row)
+	}
+	return rep.withParams(t, 1).write(w, f)
+}
+
+// milliseconds returns the planning time of res in milliseconds.
+func milliseconds(res rrt.Result) float64 {
+	return 1000 * res.WallTime.Seconds()
+}
+
+// seedRange returns the smallest and largest seed of the trials of a
+// planner.
+func (rep *Report) seedRange(planner string) (first, last int64) {
+	found := false
+	for _, t := range rep.Trials {
+		if t.Planner != planner {
+			continue
+		}
+		if !found || t.Seed < first {
+			first = t.Seed
+		}
+		if !found || t.Seed > last {
+			last = t.Seed
+		}
+		found = true
+	}
+	return first, last
+}
+
+// withParams appends one column per parameter name used by any planner,
+// in the order they first appear. col is the column holding the planner
+// name.
+func (rep *Report) withParams(t table, col int) table {
+	params := map[string][]Param{}
+	var names []string
+	seen := map[string]bool{}
+	for _, c := range rep.Comparisons {
+		params[c.Planner] = c.Params
+		for _, param := range c.Params {
+			if !seen[param.Name] {
+				seen[param.Name] = true
+				names = append(names, param.Name)
+			}
+		}
+	}
+
+	t.header = append(t.header, names...)
+	for i, row := range t.rows {
+		values := map[string]any{}
+		for _, param := range params[row[col].(string)] {
+			values[param.Name] = param.Value
+		}
+		for _, name := range names {
+			row = append(row, values[name])
+		}
+		t.rows[i] = row
+	}
+	return t
+}
+
+func (t table) write(w io.Writer, f Format) error {
+	switch f {
+	case FormatCSV:
+		return t.writeCSV(w)
+	case FormatJSONLines:
+		return t.writeJSONLines(w)
+	case FormatMarkdown:
+		return t.writeMarkdown(w)
+	case FormatLaTeX:
+		return t.writeLaTeX(w)
+	}
+	return fmt.Errorf("unknown export format %v", f)
+}
+
+func (t table) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(t.header); err != nil {
+		return err
+	}
+	for _, row := range t.rows {
+		record := make([]string, len(row))
+		for i, v := range row {
+			record[i] = formatExact(v)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeJSONLines writes every row as a JSON object whose keys follow the
+// column order.
+func (t table) writeJSONLines(w io.Writer) error {
+	for _, row := range t.rows {
+		var b strings.Builder
+		b.WriteByte('{')
+		for i, v := range row {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			key, _ := json.Marshal(t.header[i])
+			value, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			b.Write(key)
+			b.WriteByte(':')
+			b.Write(value)
+		}
+		b.WriteString("}\n")
+		if _, err := io.WriteString(w, b.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t table) writeMarkdown(w io.Writer) error {
+	line := func(cells []string) error {
+		_, err := fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | "))
+		return err
+	}
+
+	rule := make([]string, len(t.header))
+	for i := range rule {
+		rule[i] = "---"
+	}
+	if err := line(t.header); err != nil {
+		return err
+	}
+	if err := line(rule); err != nil {
+		return err
+	}
+	for _, row := range t.rows {
+		cells := make([]string, len(row))
+		for i, v := range row {
+			cells[i] = strings.ReplaceAll(formatShort(v), "|", `\|`)
+		}
+		if err := line(cells); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t table) writeLaTeX(w io.Writer) error {
+	line := func(cells []string) error {
+		for i, c := range cells {
+			cells[i] = latexEscaper.Replace(c)
+		}
+		_, err := fmt.Fprintf(w, "%s \\\\\n", strings.Join(cells, " & "))
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, "\\begin{tabular}{%s}\n\\hline\n", strings.Repeat("l", len(t.header))); err != nil {
+		return err
+	}
+	if err := line(append([]string(nil), t.header...)); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprint(w, "\\hline\n"); err != nil {
+		return err
+	}
+	for _, row := range t.rows {
+		cells := make([]string, len(row))
+		for i, v := range row {
+			cells[i] = formatShort(v)
+		}
+		if err := line(cells); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprint(w, "\\hline\n\\end{tabular}\n")
+	return err
+}
+
+// latexEscaper escapes the characters with a special meaning in LaTeX.
+var latexEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`&`, `\&`,
+	`%`, `\%`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+)
+
+// formatExact formats a cell without losing precision, for files that are
+// read back by programs.
+func formatExact(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+// formatShort formats a cell for tables read by people, with two decimals
+// for floating-point numbers.
+func formatShort(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "-"
+	case float64:
+		return strconv.FormatFloat(v, 'f', 2, 64)
+	}
+	return fmt.Sprint(v)
+}
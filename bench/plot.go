@@ -0,0 +1,100 @@
+package bench
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/plotutil"
+	"gonum.org/v1/plot/vg"
+)
+
+// PlotConvergence plots the curves of rep on the given axis: the mean best
+// cost of every planner as a line over its quantile bands, the widest band
+// palest. Each line and band starts where its value is defined, see Curve. The image format follows the extension of filename, e.g. .png or
+// .svg.
+func (rep *Report) PlotConvergence(filename string, axis Axis, levels []float64) error {
+	curves := rep.Curves(axis, 200, levels)
+	if len(curves) == 0 {
+		return fmt.Errorf("no search progress recorded")
+	}
+
+	p := plot.New()
+	p.Title.Text = rep.Scenario
+	p.X.Label.Text = "iteration"
+	if axis == AxisTime {
+		p.X.Label.Text = "time (ms)"
+	}
+	p.Y.Label.Text = "best path cost"
+	p.Legend.Top = true
+
+	for i, c := range curves {
+		cl := plotutil.Color(i)
+		label := fmt.Sprintf("%s (n=%d)", c.Planner, c.Trials)
+		if solved := c.Solved[len(c.Solved)-1]; solved < c.Trials {
+			label = fmt.Sprintf("%s (%d of %d solved)", c.Planner, solved, c.Trials)
+		}
+		var key plot.Thumbnailer
+
+		bands := append([]Band(nil), c.Bands...)
+		sort.Slice(bands, func(a, b int) bool { return bands[a].Level > bands[b].Level })
+		for k, b := range bands {
+			// 上分位数有限时下分位数也有限；代价只降不升，有限后一直有限
+			first := firstDefined(b.High)
+			if first == len(b.High) {
+				continue
+			}
+			// 上边界正向、下边界反向，围成一个多边形
+			var ring plotter.XYs
+			for j := first; j < len(c.X); j++ {
+				ring = append(ring, plotter.XY{X: c.X[j], Y: b.High[j]})
+			}
+			for j := len(c.X) - 1; j >= first; j-- {
+				ring = append(ring, plotter.XY{X: c.X[j], Y: b.Low[j]})
+			}
+			pl, err := plotter.NewPolygon(ring)
+			if err != nil {
+				return err
+			}
+			pl.Color = fade(cl, uint8(48*(k+1)/len(bands)))
+			pl.LineStyle.Width = 0
+			p.Add(pl)
+			key = pl
+		}
+
+		if first := firstDefined(c.Mean); first < len(c.Mean) {
+			var mean plotter.XYs
+			for j := first; j < len(c.X); j++ {
+				mean = append(mean, plotter.XY{X: c.X[j], Y: c.Mean[j]})
+			}
+			line, err := plotter.NewLine(mean)
+			if err != nil {
+				return err
+			}
+			line.Color = cl
+			line.Width = vg.Points(2)
+			p.Add(line)
+			key = line
+		}
+		if key != nil {
+			p.Legend.Add(label, key)
+		}
+	}
+
+	return p.Save(8*vg.Inch, 5*vg.Inch, filename)
+}
+
+// firstDefined returns the index of the first value that is not NaN, or
+// len(values) if there is none.
+func firstDefined(values []float64) int {
+	return sort.Search(len(values), func(j int) bool { return !math.IsNaN(values[j]) })
+}
+
+// fade returns cl with the given opacity.
+func fade(cl color.Color, alpha uint8) color.Color {
+	r, g, b, _ := cl.RGBA()
+	return color.NRGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: alpha}
+}
@@ -0,0 +1,68 @@
+package bench
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bz-2021/rrt_star/stats"
+)
+
+// WriteTable writes a plain-text comparison table with one row per
+// planner: number of runs, success rate, path length, planning time and
+// tree size.
+func (rep *Report) WriteTable(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "%-24s %6s %8s %10s %9s %10s %10s %9s %9s %9s\n",
+		"algorithm", "runs", "success", "cost", "±std", "median", "time(ms)", "±std", "nodes", "±std")
+	if err != nil {
+		return err
+	}
+	for _, c := range rep.Comparisons {
+		a := c.Aggregate
+		cost := fmt.Sprintf("%10s %9s %10s", "-", "-", "-")
+		if a.Cost.N > 0 {
+			cost = fmt.Sprintf("%10.2f %9.2f %10.2f", a.Cost.Mean, a.Cost.Std, a.Cost.Median)
+		}
+		_, err := fmt.Fprintf(w, "%-24s %6d %7.1f%% %s %10.2f %9.2f %9.1f %9.1f\n",
+			c.Planner, a.Runs, 100*a.SuccessRate, cost,
+			1000*a.WallTime.Mean, 1000*a.WallTime.Std, a.Nodes.Mean, a.Nodes.Std)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WritePairs writes the pairwise comparison of path lengths and of
+// planning times: the mean difference with Welch's t-test, the
+// Mann–Whitney U test and the effect sizes Cohen's d and Cliff's delta.
+func (rep *Report) WritePairs(w io.Writer) error {
+	metrics := []struct {
+		name   string
+		scale  float64
+		sample func(Pair) stats.TwoSample
+	}{
+		{"cost", 1, func(p Pair) stats.TwoSample { return p.Cost }},
+		{"time(ms)", 1000, func(p Pair) stats.TwoSample { return p.WallTime }},
+	}
+	for i, m := range metrics {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		_, err := fmt.Fprintf(w, "%-40s %10s %10s %10s %8s %8s\n",
+			m.name+": A vs B", "A-B", "welch p", "mwu p", "d", "delta")
+		if err != nil {
+			return err
+		}
+		for _, p := range rep.Pairs {
+			c := m.sample(p)
+			_, err := fmt.Fprintf(w, "%-40s %10.2f %10.3g %10.3g %8.2f %8.2f\n",
+				p.A+" vs "+p.B, m.scale*c.MeanDiff, c.Welch.P, c.MannWhitney.P, c.CohensD, c.CliffsDelta)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
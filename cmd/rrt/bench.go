@@ -0,0 +1,247 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+
+	"github.com/bz-2021/rrt_star/bench"
+	"github.com/bz-2021/rrt_star/rrt"
+	"github.com/bz-2021/rrt_star/scenario"
+	"github.com/bz-2021/rrt_star/stats"
+)
+
+// defaultAlgos are the planners compared by "rrt bench" unless -algo or
+// -algos is given.
+const defaultAlgos = "rrt,rrt+apf,rrt+improved-apf,rrtstar"
+
+func runBench(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	var pf plannerFlags
+	pf.register(fs)
+	trials := fs.Int("n", 10, "number of trials per algorithm")
+	timeout := fs.Duration("timeout", 0, "abort a trial after this long and count it as timed out (0: no limit)")
+	algos := fs.String("algos", defaultAlgos, "comma-separated algorithms to compare (default: -algo if set)")
+	verbose := fs.Bool("v", false, "print the full statistics of every algorithm")
+	workers := fs.Int("workers", 0, "number of trials run in parallel (0: GOMAXPROCS)")
+	trialsOut := fs.String("trials-out", "", "write every trial to this .csv, .jsonl, .md or .tex file")
+	summaryOut := fs.String("summary-out", "", "write the per-algorithm summary to this .csv, .jsonl, .md or .tex file")
+	plotOut := fs.String("plot", "", "plot the best cost over the search to this .png or .svg file")
+	plotX := fs.String("plot-x", "iteration", "x axis of the plot: iteration or time")
+	bands := fs.String("bands", "0.5,0.9", "comma-separated coverages of the quantile bands in the plot")
+	traceEvery := fs.Int("trace-every", 10, "iterations between the samples of the plot")
+
+	s, err := loadScenario(fs, &pf, args)
+	if err != nil {
+		return err
+	}
+	if *trials <= 0 {
+		return fmt.Errorf("bench: -n must be positive")
+	}
+	for _, out := range []string{*trialsOut, *summaryOut} {
+		if out == "" {
+			continue
+		}
+		if _, err := bench.FormatOf(out); err != nil {
+			return fmt.Errorf("bench: %w", err)
+		}
+	}
+	axis, err := bench.ParseAxis(*plotX)
+	if err != nil {
+		return fmt.Errorf("bench: -plot-x: %w", err)
+	}
+	levels, err := parseLevels(*bands)
+	if err != nil {
+		return fmt.Errorf("bench: -bands: %w", err)
+	}
+	if *plotOut != "" && *traceEvery <= 0 {
+		return fmt.Errorf("bench: -trace-every must be positive")
+	}
+
+	selected := strings.Split(*algos, ",")
+	if isSet(fs, "algo") && !isSet(fs, "algos") {
+		selected = []string{algoName(s.Planner)}
+	}
+	planners, err := benchPlanners(s, selected)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// 所有算法的第 i 次试验都使用种子 seed+i
+	runner := &bench.Runner{
+		Scenario: s.Name,
+		Planners: planners,
+		Trials:   *trials,
+		Seed:     pf.seedOrNow(fs),
+		Timeout:  *timeout,
+		Workers:  *workers,
+	}
+	if *plotOut != "" {
+		runner.TraceEvery = *traceEvery
+	}
+	report, runErr := runner.Run(ctx)
+	if len(report.Comparisons) == 0 {
+		return runErr
+	}
+	if runErr != nil {
+		fmt.Fprintf(os.Stderr, "bench interrupted after %d trials\n", len(report.Trials))
+	}
+
+	fmt.Printf("%s, %d trials per algorithm, seeds %d..%d\n\n", s.Name, *trials, runner.Seed, runner.Seed+int64(*trials)-1)
+	if err := report.WriteTable(os.Stdout); err != nil {
+		return err
+	}
+	if len(report.Pairs) > 0 {
+		fmt.Println()
+		if err := report.WritePairs(os.Stdout); err != nil {
+			return err
+		}
+	}
+	for _, c := range report.Comparisons {
+		if c.Timeouts > 0 {
+			fmt.Printf("%s: %d of %d trials timed out\n", c.Planner, c.Timeouts, c.Aggregate.Runs)
+		}
+		if *verbose {
+			fmt.Printf("\n%s\n", c.Planner)
+			printSummaries(os.Stdout, c.Aggregate)
+		}
+	}
+
+	if *trialsOut != "" {
+		if err := export(*trialsOut, report.WriteTrials); err != nil {
+			return err
+		}
+	}
+	if *summaryOut != "" {
+		if err := export(*summaryOut, report.WriteSummary); err != nil {
+			return err
+		}
+	}
+	if *plotOut != "" {
+		if err := report.PlotConvergence(*plotOut, axis, levels); err != nil {
+			return err
+		}
+	}
+	return runErr
+}
+
+// parseLevels parses a comma-separated list of band coverages between 0
+// and 1.
+func parseLevels(s string) ([]float64, error) {
+	var levels []float64
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		level, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return nil, err
+		}
+		if level <= 0 || level >= 1 {
+			return nil, fmt.Errorf("coverage %v is not between 0 and 1", level)
+		}
+		levels = append(levels, level)
+	}
+	return levels, nil
+}
+
+// export writes a report to filename in the format given by its extension.
+func export(filename string, write func(io.Writer, bench.Format) error) error {
+	format, err := bench.FormatOf(filename)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := write(f, format); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// benchPlanners returns one planner per algorithm selector, each a copy
+// of the scenario planner with the algorithm replaced.
+func benchPlanners(s *scenario.Scenario, algos []string) ([]bench.Planner, error) {
+	var planners []bench.Planner
+	for _, algo := range algos {
+		mode, steering, err := parseAlgo(strings.TrimSpace(algo))
+		if err != nil {
+			return nil, err
+		}
+		sc := *s
+		sc.Planner.Algorithm, sc.Planner.Steering = mode, steering
+		planners = append(planners, bench.Planner{
+			Name:   algoName(sc.Planner),
+			New:    sc.NewRRT,
+			Params: benchParams(sc.Planner),
+		})
+	}
+	return planners, nil
+}
+
+// benchParams lists the settings of p under their scenario file keys.
+// Settings that p's algorithm does not use are left out.
+func benchParams(p scenario.Planner) []bench.Param {
+	params := []bench.Param{
+		{Name: "step", Value: p.Step},
+		{Name: "bias", Value: p.Bias},
+		{Name: "goal_prob", Value: p.GoalProb},
+		{Name: "max_nodes", Value: p.MaxNodes},
+		{Name: "out_of_bounds", Value: p.OutOfBounds},
+		{Name: "anytime", Value: p.Anytime},
+		{Name: "time_budget", Value: p.TimeBudget.String()},
+	}
+	mode, _ := rrt.ParseMode(p.Algorithm)
+	switch mode {
+	case rrt.ModeRRTStar, rrt.ModeInformed:
+		params = append(params, bench.Param{Name: "gamma", Value: p.Gamma})
+	case rrt.ModeSpaceTime:
+		params = append(params,
+			bench.Param{Name: "max_speed", Value: p.MaxSpeed},
+			bench.Param{Name: "max_wait", Value: p.MaxWait})
+	}
+	if p.Steering != "straight" {
+		params = append(params,
+			bench.Param{Name: "wall_repulsion", Value: p.WallRepulsion},
+			bench.Param{Name: "apf.kp", Value: p.APF.Kp},
+			bench.Param{Name: "apf.krep", Value: p.APF.Krep},
+			bench.Param{Name: "apf.p0", Value: p.APF.P0})
+	}
+	if p.Steering == "improved-apf" {
+		params = append(params,
+			bench.Param{Name: "apf.speed", Value: p.APF.Speed},
+			bench.Param{Name: "apf.schedule", Value: p.APF.Schedule})
+	}
+	return params
+}
+
+// printSummaries prints one row per statistic of agg.
+func printSummaries(w io.Writer, agg stats.Aggregate) {
+	fmt.Fprintf(w, "  %-12s %10s %10s %10s %10s %10s %21s\n", "", "mean", "std", "median", "min", "max", "95% CI of mean")
+	row := func(name string, s stats.Summary, scale float64) {
+		if s.N == 0 {
+			return
+		}
+		fmt.Fprintf(w, "  %-12s %10.2f %10.2f %10.2f %10.2f %10.2f %21s\n", name,
+			s.Mean*scale, s.Std*scale, s.Median*scale, s.Min*scale, s.Max*scale, fmt.Sprintf("%.2f..%.2f", s.CILow*scale, s.CIHigh*scale))
+	}
+	row("cost", agg.Cost, 1)
+	row("waypoints", agg.Waypoints, 1)
+	row("iterations", agg.Iterations, 1)
+	row("nodes", agg.Nodes, 1)
+	row("checks", agg.CollisionChecks, 1)
+	row("escapes", agg.Escapes, 1)
+	row("time (ms)", agg.WallTime, 1000)
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/bz-2021/rrt_star/rrt"
+	"github.com/bz-2021/rrt_star/scenario"
+)
+
+// plannerFlags are the planner settings that can override a scenario.
+type plannerFlags struct {
+	algo     string
+	step     float64
+	bias     float64
+	goalProb float64
+	maxNodes int
+	kp       float64
+	krep     float64
+	p0       float64
+	schedule string
+	maxSpeed float64
+	bounds   string
+	walls    bool
+	maxWait  float64
+	anytime  bool
+	budget   time.Duration
+	seed     int64
+}
+
+func (f *plannerFlags) register(fs *flag.FlagSet) {
+	def := scenario.DefaultPlanner()
+	fs.StringVar(&f.algo, "algo", "", "algorithm as mode[+steering], e.g. rrt, rrt+apf, rrt+improved-apf, rrtstar, informed, spacetime, connect")
+	fs.Float64Var(&f.step, "step", def.Step, "step size")
+	fs.Float64Var(&f.bias, "bias", def.Bias, "distance at which the goal counts as reached")
+	fs.Float64Var(&f.goalProb, "goal-prob", def.GoalProb, "probability of sampling the goal")
+	fs.IntVar(&f.maxNodes, "max-nodes", def.MaxNodes, "maximum number of iterations")
+	fs.Float64Var(&f.kp, "kp", def.APF.Kp, "APF attractive gain")
+	fs.Float64Var(&f.krep, "krep", def.APF.Krep, "APF repulsive gain")
+	fs.Float64Var(&f.p0, "p0", def.APF.P0, "APF repulsion range")
+	fs.StringVar(&f.schedule, "schedule", "", "improved APF range schedule: iteration:N, tree_size:N or goal_distance:D")
+	fs.StringVar(&f.bounds, "out-of-bounds", def.OutOfBounds, "what to do with new nodes outside the map: reject or clamp")
+	fs.BoolVar(&f.walls, "walls", def.WallRepulsion, "let the map border repel APF steering like an obstacle")
+	fs.Float64Var(&f.maxSpeed, "max-speed", def.MaxSpeed, "maximum speed of the spacetime planner")
+	fs.Float64Var(&f.maxWait, "max-wait", def.MaxWait, "longest wait at a node of the spacetime planner")
+	fs.BoolVar(&f.anytime, "anytime", def.Anytime, "keep improving the path after the first solution")
+	fs.DurationVar(&f.budget, "budget", def.TimeBudget, "planning time budget, e.g. 200ms (0: no limit)")
+	fs.Int64Var(&f.seed, "seed", 0, "random seed (default: derived from the current time)")
+}
+
+// isSet reports whether the flag with the given name was set on the
+// command line.
+func isSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(fl *flag.Flag) {
+		if fl.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// seedOrNow returns the -seed flag if it was set and a time-based seed
+// otherwise.
+func (f *plannerFlags) seedOrNow(fs *flag.FlagSet) int64 {
+	if isSet(fs, "seed") {
+		return f.seed
+	}
+	return time.Now().UnixNano()
+}
+
+// apply overrides the scenario planner with the flags set on the command
+// line. The first invalid flag is reported.
+func (f *plannerFlags) apply(fs *flag.FlagSet, s *scenario.Scenario) error {
+	var err error
+	fs.Visit(func(fl *flag.Flag) {
+		if err != nil {
+			return
+		}
+		p := &s.Planner
+		switch fl.Name {
+		case "algo":
+			p.Algorithm, p.Steering, err = parseAlgo(f.algo)
+		case "step":
+			err = checkFloat("-step", f.step, f.step > 0, "must be positive")
+			p.Step = f.step
+		case "bias":
+			err = checkFloat("-bias", f.bias, f.bias > 0, "must be positive")
+			p.Bias = f.bias
+		case "goal-prob":
+			err = checkFloat("-goal-prob", f.goalProb, f.goalProb >= 0 && f.goalProb <= 1, "must be between 0 and 1")
+			p.GoalProb = f.goalProb
+		case "max-nodes":
+			if f.maxNodes < 0 {
+				err = fmt.Errorf("-max-nodes must not be negative")
+			}
+			p.MaxNodes = f.maxNodes
+		case "kp":
+			err = checkFloat("-kp", f.kp, true, "")
+			p.APF.Kp = f.kp
+		case "krep":
+			err = checkFloat("-krep", f.krep, true, "")
+			p.APF.Krep = f.krep
+		case "p0":
+			err = checkFloat("-p0", f.p0, f.p0 > 0, "must be positive")
+			p.APF.P0 = f.p0
+		case "out-of-bounds":
+			if _, perr := rrt.ParseBoundsPolicy(f.bounds); perr != nil {
+				err = fmt.Errorf("-out-of-bounds: %w", perr)
+			}
+			p.OutOfBounds = f.bounds
+		case "walls":
+			p.WallRepulsion = f.walls
+		case "max-speed":
+			err = checkFloat("-max-speed", f.maxSpeed, f.maxSpeed > 0, "must be positive")
+			p.MaxSpeed = f.maxSpeed
+		case "max-wait":
+			err = checkFloat("-max-wait", f.maxWait, f.maxWait >= 0, "must not be negative")
+			p.MaxWait = f.maxWait
+		case "anytime":
+			p.Anytime = f.anytime
+		case "budget":
+			if f.budget < 0 {
+				err = fmt.Errorf("-budget must not be negative")
+			}
+			p.TimeBudget = f.budget
+		case "schedule":
+			if _, perr := scenario.ParseSchedule(f.schedule); perr != nil {
+				err = fmt.Errorf("-schedule: %w", perr)
+			}
+			p.APF.Schedule = f.schedule
+		}
+	})
+	if err == nil && s.Planner.MaxNodes == 0 && s.Planner.TimeBudget == 0 {
+		// 只有设置了时间预算时才允许不限迭代次数
+		err = fmt.Errorf("-max-nodes must be positive without -budget")
+	}
+	return err
+}
+
+// checkFloat applies the rules of the scenario loader to a number flag:
+// it must be finite and ok must hold, otherwise rule is reported.
+func checkFloat(name string, v float64, ok bool, rule string) error {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return fmt.Errorf("%s must be a finite number", name)
+	}
+	if !ok {
+		return fmt.Errorf("%s %s", name, rule)
+	}
+	return nil
+}
+
+// parseAlgo splits an algorithm selector such as "rrt+apf" into the mode
+// and steering names. Without a steering part the tree grows in straight
+// lines.
+func parseAlgo(algo string) (string, string, error) {
+	mode, steering, _ := strings.Cut(algo, "+")
+	if steering == "" {
+		steering = "straight"
+	}
+
+	if _, err := rrt.ParseMode(mode); err != nil {
+		return "", "", err
+	}
+	if _, err := scenario.NewExtender(steering, scenario.APF{}); err != nil {
+		return "", "", err
+	}
+	return mode, steering, nil
+}
+
+// algoName formats the planner settings as an algorithm selector.
+func algoName(p scenario.Planner) string {
+	if p.Steering == "straight" {
+		return p.Algorithm
+	}
+	return p.Algorithm + "+" + p.Steering
+}
+
+// withTimeout returns a child of parent that is canceled after timeout, or
+// only together with parent if timeout is not positive.
+func withTimeout(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(parent)
+	}
+	return context.WithTimeout(parent, timeout)
+}
+
+// loadScenario parses the flags, loads the single scenario argument and
+// applies the planner overrides.
+func loadScenario(fs *flag.FlagSet, pf *plannerFlags, args []string) (*scenario.Scenario, error) {
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return nil, fmt.Errorf("%s: expected exactly one scenario file", fs.Name())
+	}
+
+	s, err := scenario.Load(fs.Arg(0))
+	if err != nil {
+		return nil, err
+	}
+	if pf != nil {
+		if err := pf.apply(fs, s); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
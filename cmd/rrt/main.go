@@ -0,0 +1,57 @@
+// Command rrt plans, renders and benchmarks the RRT variants of this
+// module on scenario files.
+//
+// Usage:
+//
+//	rrt plan     [flags] <scenario>   run one planner and print the result
+//	rrt render   [flags] <scenario>   draw the scenario and an optional path
+//	rrt bench    [flags] <scenario>   compare algorithms over seeded trials
+//	rrt validate <scenario>...        check scenario files for errors
+//
+// Run "rrt <command> -h" for the flags of a command.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+)
+
+var commands = map[string]func(args []string) error{
+	"plan":     runPlan,
+	"render":   runRender,
+	"bench":    runBench,
+	"validate": runValidate,
+}
+
+func usage() {
+	fmt.Fprint(os.Stderr, `usage: rrt <command> [flags] <scenario>
+
+commands:
+  plan      run one planner and print the result
+  render    draw the scenario and an optional path
+  bench     compare algorithms over seeded trials
+  validate  check scenario files for errors
+`)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	run, ok := commands[os.Args[1]]
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+	if err := run(os.Args[2:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(2)
+		}
+		fmt.Fprintln(os.Stderr, "rrt:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bz-2021/rrt_star/rrt"
+	"github.com/bz-2021/rrt_star/scenario"
+)
+
+// planOutput is the JSON written by "rrt plan -o result.json" and read by
+// "rrt render -path".
+type planOutput struct {
+	Scenario   string      `json:"scenario"`
+	Algorithm  string      `json:"algorithm"`
+	Success    bool        `json:"success"`
+	Cost       float64     `json:"cost"`
+	Iterations int         `json:"iterations"`
+	Nodes      int         `json:"nodes"`
+	Escapes    int         `json:"escapes"`
+	Waypoints  int         `json:"waypoints"`
+	Checks     int         `json:"collision_checks"`
+	WallTime   float64     `json:"wall_time_ms"`
+	Seed       int64       `json:"seed"`
+	Path       []rrt.Point `json:"path"`
+	Times      []float64   `json:"times,omitempty"`
+
+	Convergence []rrt.CostSample `json:"convergence,omitempty"`
+}
+
+func runPlan(args []string) error {
+	fs := flag.NewFlagSet("plan", flag.ContinueOnError)
+	var pf plannerFlags
+	pf.register(fs)
+	out := fs.String("o", "", "output file: .json for the result, .png/.svg/.pdf for a plot of the tree")
+	timeout := fs.Duration("timeout", 0, "abort planning after this long and keep the best path so far (0: no limit)")
+
+	s, err := loadScenario(fs, &pf, args)
+	if err != nil {
+		return err
+	}
+	r, err := s.NewRRT()
+	if err != nil {
+		return err
+	}
+	r.Seed = pf.seedOrNow(fs)
+	if r.Anytime {
+		r.OnImprove = func(iteration int, cost float64, path []rrt.Point) {
+			fmt.Printf("iteration %d: cost=%.2f waypoints=%d\n", iteration, cost, len(path))
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ctx, cancel := withTimeout(ctx, *timeout)
+	defer cancel()
+	result, planErr := r.PlanContext(ctx)
+	fmt.Printf("%s on %s: success=%t cost=%.2f waypoints=%d iterations=%d nodes=%d checks=%d escapes=%d time=%v seed=%d\n",
+		algoName(s.Planner), s.Name, result.Success, result.Cost, result.Waypoints, result.Iterations, result.Nodes,
+		result.CollisionChecks, result.Escapes, result.WallTime.Round(time.Microsecond), result.Seed)
+	if n := len(result.Times); n > 0 {
+		fmt.Printf("arrival time %.2f\n", result.Times[n-1])
+	}
+
+	if *out != "" {
+		var err error
+		if strings.EqualFold(filepath.Ext(*out), ".json") {
+			err = writePlan(*out, s, result)
+		} else {
+			err = rrt.PlotRRT(r, *out)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return planErr
+}
+
+func writePlan(filename string, s *scenario.Scenario, result rrt.Result) error {
+	data, err := json.MarshalIndent(planOutput{
+		Scenario:   s.Name,
+		Algorithm:  algoName(s.Planner),
+		Success:    result.Success,
+		Cost:       result.Cost,
+		Iterations: result.Iterations,
+		Nodes:      result.Nodes,
+		Escapes:    result.Escapes,
+		Waypoints:  result.Waypoints,
+		Checks:     result.CollisionChecks,
+		WallTime:   float64(result.WallTime.Microseconds()) / 1000,
+		Seed:       result.Seed,
+		Path:       result.Path,
+		Times:      result.Times,
+
+		Convergence: result.Convergence,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/bz-2021/rrt_star/rrt"
+)
+
+func runRender(args []string) error {
+	fs := flag.NewFlagSet("render", flag.ContinueOnError)
+	out := fs.String("o", "scenario.png", "output image (.png, .svg or .pdf)")
+	pathFile := fs.String("path", "", "result JSON written by \"rrt plan -o\" to draw on top of the scenario")
+
+	s, err := loadScenario(fs, nil, args)
+	if err != nil {
+		return err
+	}
+	r, err := s.NewRRT()
+	if err != nil {
+		return err
+	}
+
+	if *pathFile != "" {
+		data, err := os.ReadFile(*pathFile)
+		if err != nil {
+			return err
+		}
+		var plan planOutput
+		if err := json.Unmarshal(data, &plan); err != nil {
+			return fmt.Errorf("%s: %w", *pathFile, err)
+		}
+		r.Path = plan.Path
+	}
+	return rrt.PlotRRT(r, *out)
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/bz-2021/rrt_star/scenario"
+)
+
+func runValidate(args []string) error {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return errors.New("validate: expected at least one scenario file")
+	}
+
+	failed := 0
+	for _, file := range fs.Args() {
+		s, err := scenario.Load(file)
+		if err == nil {
+			_, err = s.NewRRT()
+		}
+		if err != nil {
+			fmt.Println(err)
+			failed++
+			continue
+		}
+		fmt.Printf("%s: ok (%d obstacles)\n", file, len(s.Obstacles))
+	}
+	if failed > 0 {
+		return fmt.Errorf("validate: %d of %d files invalid", failed, fs.NArg())
+	}
+	return nil
+}
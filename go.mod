@@ -2,6 +2,11 @@ module github.com/bz-2021/rrt_star
 
 go 1.23.2
 
+require (
+	gonum.org/v1/plot v0.15.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
 require (
 	git.sr.ht/~sbinet/gg v0.6.0 // indirect
 	github.com/ajstarks/svgo v0.0.0-20211024235047-1546f124cd8b // indirect
@@ -13,5 +18,4 @@ require (
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	golang.org/x/image v0.21.0 // indirect
 	golang.org/x/text v0.19.0 // indirect
-	gonum.org/v1/plot v0.15.0 // indirect
 )
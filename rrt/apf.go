@@ -0,0 +1,92 @@
+package rrt
+
+import (
+	"math"
+)
+
+// APF extends the tree using an artificial potential field: the step
+// direction combines the pull towards the random point, the attraction of
+// the goal and the repulsion of nearby obstacles.
+type APF struct {
+	Kp   float64 // 引力增益系数
+	Krep float64 // 斥力增益系数
+	P0   float64 // 斥力作用范围
+}
+
+// NewAPF creates a new APF extension strategy.
+func NewAPF(kp, krep, p0 float64) *APF {
+	return &APF{
+		Kp:   kp,
+		Krep: krep,
+		P0:   p0,
+	}
+}
+
+// Extend implements Extender.
+func (a *APF) Extend(r *RRT, nearestPoint, randomPoint Point) Point {
+	// 随机点与最近节点之间的距离
+	len := r.EuclideanDistance(nearestPoint, randomPoint)
+	if len == 0 {
+		return nearestPoint
+	}
+
+	// 合斥力方向
+	F := Point{}
+	for _, obs := range r.Obstacles {
+		f := repulsion(r, nearestPoint, obs, a.P0)
+		F.X += f.X
+		F.Y += f.Y
+	}
+	w := wallRepulsion(r, nearestPoint, a.P0)
+	F.X += w.X
+	F.Y += w.Y
+	if norm := math.Hypot(F.X, F.Y); norm != 0 {
+		F = Point{X: a.Krep * F.X / norm, Y: a.Krep * F.Y / norm}
+	}
+
+	// 随机点方向 + 目标引力 + 斥力
+	U := Point{
+		X: (randomPoint.X-nearestPoint.X)/len + F.X,
+		Y: (randomPoint.Y-nearestPoint.Y)/len + F.Y,
+	}
+	if len1 := r.EuclideanDistance(nearestPoint, r.Goal); len1 != 0 {
+		U.X += a.Kp * (r.Goal.X - nearestPoint.X) / len1
+		U.Y += a.Kp * (r.Goal.Y - nearestPoint.Y) / len1
+	}
+	normU := math.Hypot(U.X, U.Y)
+	if normU == 0 {
+		return nearestPoint
+	}
+
+	step := math.Min(r.Step, len)
+	return Point{
+		X: nearestPoint.X + step*U.X/normU,
+		Y: nearestPoint.Y + step*U.Y/normU,
+	}
+}
+
+// minRepulsionDistance keeps the repulsive force finite on the boundary.
+const minRepulsionDistance = 1e-3
+
+// repulsion computes the repulsive force of an obstacle acting on p. The
+// force is measured from the closest point on the inflated boundary and
+// vanishes beyond p0. Inside the obstacle it points towards the nearest
+// edge.
+func repulsion(r *RRT, p Point, obs Obstacle, p0 float64) Point {
+	d := obs.Distance(p, r.InfluenceRange)
+	if d > p0 {
+		return Point{}
+	}
+
+	dir := r.Direction(obs.ClosestPoint(p, r.InfluenceRange), p)
+	if d <= 0 {
+		dir = Point{X: -dir.X, Y: -dir.Y}
+	}
+	if math.IsNaN(dir.X) || math.IsNaN(dir.Y) {
+		return Point{}
+	}
+
+	d = math.Max(d, minRepulsionDistance)
+	f := (1/d - 1/p0) / (d * d)
+	return Point{X: f * dir.X, Y: f * dir.Y}
+}
@@ -0,0 +1,90 @@
+package rrt
+
+import (
+	"fmt"
+	"math"
+)
+
+// BoundsPolicy decides what happens to a new node outside the workspace.
+type BoundsPolicy int
+
+const (
+	BoundsReject BoundsPolicy = iota // 丢弃超出地图的新节点
+	BoundsClamp                      // 把新节点截断到地图边界上
+)
+
+var boundsPolicyNames = map[BoundsPolicy]string{
+	BoundsReject: "reject",
+	BoundsClamp:  "clamp",
+}
+
+// String returns the name of the policy as accepted by ParseBoundsPolicy.
+func (b BoundsPolicy) String() string {
+	if name, ok := boundsPolicyNames[b]; ok {
+		return name
+	}
+	return fmt.Sprintf("BoundsPolicy(%d)", int(b))
+}
+
+// ParseBoundsPolicy returns the policy with the given name.
+func ParseBoundsPolicy(name string) (BoundsPolicy, error) {
+	for b, n := range boundsPolicyNames {
+		if n == name {
+			return b, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown bounds policy %q", name)
+}
+
+// InBounds checks if p lies inside the workspace, borders included.
+func (r *RRT) InBounds(p Point) bool {
+	return p.X >= r.XMin && p.X <= r.XMax && p.Y >= r.YMin && p.Y <= r.YMax
+}
+
+// clamp moves p onto the workspace border if it lies outside.
+func (r *RRT) clamp(p Point) Point {
+	return Point{
+		X: math.Min(math.Max(p.X, r.XMin), r.XMax),
+		Y: math.Min(math.Max(p.Y, r.YMin), r.YMax),
+	}
+}
+
+// applyBounds clamps p under BoundsClamp. Under BoundsReject points
+// outside are left alone and refused by the edge check.
+func (r *RRT) applyBounds(p Point) Point {
+	if r.OutOfBounds == BoundsClamp {
+		return r.clamp(p)
+	}
+	return p
+}
+
+// wallRepulsion is the repulsion of the workspace border acting on p when
+// WallRepulsion is set. Each of the four walls pushes inwards like an
+// obstacle with range p0.
+func wallRepulsion(r *RRT, p Point, p0 float64) Point {
+	F := Point{}
+	if !r.WallRepulsion {
+		return F
+	}
+
+	// 到四面墙的距离和指向地图内部的方向
+	walls := []struct {
+		d   float64
+		dir Point
+	}{
+		{p.X - r.XMin, Point{X: 1}},
+		{r.XMax - p.X, Point{X: -1}},
+		{p.Y - r.YMin, Point{Y: 1}},
+		{r.YMax - p.Y, Point{Y: -1}},
+	}
+	for _, w := range walls {
+		if w.d > p0 {
+			continue
+		}
+		d := math.Max(w.d, minRepulsionDistance)
+		f := (1/d - 1/p0) / (d * d)
+		F.X += f * w.dir.X
+		F.Y += f * w.dir.Y
+	}
+	return F
+}
@@ -0,0 +1,51 @@
+package rrt
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+)
+
+// Errors returned by PlanContext when its context ends the search. The
+// result returned alongside them holds the best path found until then.
+var (
+	ErrCanceled = errors.New("planning canceled")
+	ErrTimeout  = errors.New("planning deadline exceeded")
+)
+
+// budget decides when a planning run has to stop.
+type budget struct {
+	ctx           context.Context
+	deadline      time.Time // TimeBudget 用尽的时刻，零值表示不限制
+	maxIterations int
+}
+
+// newBudget returns the limits of a run from NumNodes, TimeBudget and ctx.
+func (r *RRT) newBudget(ctx context.Context) budget {
+	b := budget{ctx: ctx, maxIterations: r.NumNodes}
+	if r.TimeBudget > 0 {
+		b.deadline = time.Now().Add(r.TimeBudget)
+		if b.maxIterations <= 0 {
+			b.maxIterations = math.MaxInt
+		}
+	}
+	return b
+}
+
+// next reports whether another iteration may run after the given number
+// of iterations. It returns ErrCanceled or ErrTimeout if the context ended;
+// reaching the iteration cap or the time budget is not an error.
+func (b budget) next(iterations int) (bool, error) {
+	switch b.ctx.Err() {
+	case nil:
+	case context.DeadlineExceeded:
+		return false, ErrTimeout
+	default:
+		return false, ErrCanceled
+	}
+	if !b.deadline.IsZero() && time.Now().After(b.deadline) {
+		return false, nil
+	}
+	return iterations < b.maxIterations, nil
+}
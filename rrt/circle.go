@@ -0,0 +1,67 @@
+package rrt
+
+import (
+	"math"
+)
+
+// circleSegments is the number of segments used to draw a circle.
+const circleSegments = 64
+
+// Circle represents a round obstacle such as a pillar.
+type Circle struct {
+	Center Point
+	Radius float64
+}
+
+// NewCircle creates a new Circle instance.
+func NewCircle(center Point, radius float64) *Circle {
+	return &Circle{
+		Center: center,
+		Radius: radius,
+	}
+}
+
+// Contains checks if a point (x, y) is inside the obstacle.
+func (c *Circle) Contains(x, y float64) bool {
+	return math.Hypot(x-c.Center.X, y-c.Center.Y) <= c.Radius
+}
+
+// IntersectsSegment implements Obstacle.
+func (c *Circle) IntersectsSegment(p1, p2 Point, influenceRange float64) bool {
+	q := closestOnSegment(c.Center, p1, p2)
+	return math.Hypot(q.X-c.Center.X, q.Y-c.Center.Y) <= c.Radius+influenceRange
+}
+
+// ClosestPoint implements Obstacle.
+func (c *Circle) ClosestPoint(p Point, influenceRange float64) Point {
+	radius := c.Radius + influenceRange
+	d := math.Hypot(p.X-c.Center.X, p.Y-c.Center.Y)
+	if d == 0 {
+		return Point{X: c.Center.X + radius, Y: c.Center.Y}
+	}
+	return Point{
+		X: c.Center.X + (p.X-c.Center.X)/d*radius,
+		Y: c.Center.Y + (p.Y-c.Center.Y)/d*radius,
+	}
+}
+
+// Distance implements Obstacle.
+func (c *Circle) Distance(p Point, influenceRange float64) float64 {
+	return math.Hypot(p.X-c.Center.X, p.Y-c.Center.Y) - c.Radius - influenceRange
+}
+
+// GetBounds implements Obstacle.
+func (c *Circle) GetBounds(influenceRange float64) (float64, float64, float64, float64) {
+	radius := c.Radius + influenceRange
+	return c.Center.X - radius, c.Center.Y - radius, 2 * radius, 2 * radius
+}
+
+// Outline implements Obstacle.
+func (c *Circle) Outline() []Point {
+	pts := make([]Point, 0, circleSegments+1)
+	for i := 0; i <= circleSegments; i++ {
+		sin, cos := math.Sincos(2 * math.Pi * float64(i) / circleSegments)
+		pts = append(pts, Point{X: c.Center.X + c.Radius*cos, Y: c.Center.Y + c.Radius*sin})
+	}
+	return pts
+}
@@ -0,0 +1,150 @@
+package rrt
+
+import (
+	"context"
+	"math"
+)
+
+// extendStatus is the outcome of growing a tree towards a point.
+type extendStatus int
+
+const (
+	trapped  extendStatus = iota // 新的边发生碰撞，树没有生长
+	advanced                     // 向目标点前进了一步
+	reached                      // 到达了目标点
+)
+
+// planConnect runs RRT-Connect: a second tree grows from the goal, and
+// after every extension of one tree the other one greedily connects to the
+// new node. The trees swap roles every iteration.
+func (r *RRT) planConnect(ctx context.Context) (Result, error) {
+	r.goalTree = r.newGoalTree()
+
+	a, b := r, r.goalTree
+	meetA, meetB := -1, -1
+	iterations := 0
+	limit := r.newBudget(ctx)
+	var stopErr error
+	for {
+		r.record(iterations, math.Inf(1), false)
+		more, err := limit.next(iterations)
+		if !more {
+			stopErr = err
+			break
+		}
+		iterations++
+		// 两棵树共用迭代次数，斥力范围调度才会同步变化
+		r.iteration = iterations
+		r.goalTree.iteration = iterations
+
+		q := r.sampleSpace()
+		if status, index := a.growTowards(q, true); status != trapped {
+			if status, other := b.connectTo(a.PathV[index]); status == reached {
+				meetA, meetB = index, other
+				break
+			}
+		}
+		a, b = b, a
+	}
+
+	result := Result{
+		Iterations: iterations,
+		Nodes:      len(r.PathV) + len(r.goalTree.PathV),
+		Seed:       r.Seed,
+	}
+	if meetA == -1 {
+		r.record(iterations, math.Inf(1), true)
+		return result, stopErr
+	}
+
+	// a 和 b 在最后一次迭代中可能已经交换过
+	startIndex, goalIndex := meetA, meetB
+	if a != r {
+		startIndex, goalIndex = meetB, meetA
+	}
+	r.ExtractPath(startIndex)
+	for i := r.goalTree.Parent[goalIndex]; i != -1; i = r.goalTree.Parent[i] {
+		r.Path = append(r.Path, r.goalTree.PathV[i])
+	}
+
+	result.Success = true
+	result.Path = r.Path
+	result.Cost = PathLength(r.Path)
+	result.Convergence = recordCost(nil, iterations, result.Cost)
+	r.record(iterations, result.Cost, true)
+	if r.OnImprove != nil {
+		r.OnImprove(iterations, result.Cost, r.Path)
+	}
+	return result, nil
+}
+
+// newGoalTree returns a tree rooted at Goal that shares the obstacles,
+// settings and random source of r.
+func (r *RRT) newGoalTree() *RRT {
+	t := &RRT{
+		Mode:           r.Mode,
+		Start:          r.Goal,
+		Goal:           r.Start,
+		Step:           r.Step,
+		Bias:           r.Bias,
+		NumNodes:       r.NumNodes,
+		XMin:           r.XMin,
+		YMin:           r.YMin,
+		XMax:           r.XMax,
+		YMax:           r.YMax,
+		OutOfBounds:    r.OutOfBounds,
+		WallRepulsion:  r.WallRepulsion,
+		Obstacles:      r.Obstacles,
+		InfluenceRange: r.InfluenceRange,
+		Extender:       r.Extender,
+		Seed:           r.Seed,
+	}
+	t.reset()
+	t.rng = r.Rand()
+	return t
+}
+
+// growTowards extends the tree one step from its nearest node towards q
+// and returns the status and the index of the new node. With steer set
+// the configured Extender picks the new node, otherwise it is NewPoint.
+func (r *RRT) growTowards(q Point, steer bool) (extendStatus, int) {
+	nearestPoint, nearestIndex := r.NearestPoint(q)
+	r.extending = nearestIndex
+
+	newPoint := r.NewPoint(nearestPoint, q)
+	if steer {
+		newPoint = r.extend(nearestPoint, q)
+	}
+	newPoint = r.applyBounds(newPoint)
+	if newPoint == nearestPoint || !r.InBounds(newPoint) || !r.NoCollision(nearestPoint, newPoint) {
+		return trapped, -1
+	}
+
+	index := r.addNode(newPoint, nearestIndex)
+	if newPoint == q {
+		return reached, index
+	}
+	return advanced, index
+}
+
+// connectTo extends the tree towards q in straight steps until it reaches
+// q or collides.
+func (r *RRT) connectTo(q Point) (extendStatus, int) {
+	for {
+		status, index := r.growTowards(q, false)
+		if status != advanced {
+			return status, index
+		}
+	}
+}
+
+// treeEdges returns the edges of the tree and, after an RRT-Connect run,
+// those of the goal tree.
+func (r *RRT) treeEdges() [][2]Point {
+	if r.goalTree == nil {
+		return r.PathE
+	}
+	edges := make([][2]Point, 0, len(r.PathE)+len(r.goalTree.PathE))
+	edges = append(edges, r.PathE...)
+	return append(edges, r.goalTree.PathE...)
+}
@@ -0,0 +1,44 @@
+package rrt
+
+// Extender decides where a new node is placed when the tree grows from
+// nearestPoint towards randomPoint.
+type Extender interface {
+	Extend(r *RRT, nearestPoint, randomPoint Point) Point
+}
+
+// StraightLine extends the tree along the straight line to the random
+// point by at most one Step. It is the default strategy.
+type StraightLine struct{}
+
+// Extend implements Extender.
+func (StraightLine) Extend(r *RRT, nearestPoint, randomPoint Point) Point {
+	return r.NewPoint(nearestPoint, randomPoint)
+}
+
+// extend grows the tree using the configured strategy.
+func (r *RRT) extend(nearestPoint, randomPoint Point) Point {
+	if r.Extender == nil {
+		return r.applyBounds(r.NewPoint(nearestPoint, randomPoint))
+	}
+	return r.applyBounds(r.Extender.Extend(r, nearestPoint, randomPoint))
+}
+
+// Escaper is implemented by extension strategies that can move away from a
+// local minimum when the regular extension collides with an obstacle.
+type Escaper interface {
+	Escape(r *RRT, nearestPoint Point) (Point, bool)
+}
+
+// escape asks the configured strategy for an escape point and reports
+// whether one was found. The caller checks the edge to it for collisions.
+func (r *RRT) escape(nearestPoint Point) (Point, bool) {
+	e, ok := r.Extender.(Escaper)
+	if !ok {
+		return Point{}, false
+	}
+	escapePoint, ok := e.Escape(r, nearestPoint)
+	if !ok {
+		return Point{}, false
+	}
+	return r.applyBounds(escapePoint), true
+}
@@ -0,0 +1,148 @@
+package rrt
+
+import (
+	"math"
+)
+
+// segmentIntersectsRect clips the segment p1-p2 against the closed box
+// [minX, maxX]×[minY, maxY] (Liang–Barsky) and reports whether anything is
+// left.
+func segmentIntersectsRect(p1, p2 Point, minX, minY, maxX, maxY float64) bool {
+	dx, dy := p2.X-p1.X, p2.Y-p1.Y
+	t0, t1 := 0.0, 1.0
+
+	for _, edge := range [4][2]float64{
+		{-dx, p1.X - minX},
+		{dx, maxX - p1.X},
+		{-dy, p1.Y - minY},
+		{dy, maxY - p1.Y},
+	} {
+		p, q := edge[0], edge[1]
+		if p == 0 {
+			// 线段与该边平行，且位于边界外侧
+			if q < 0 {
+				return false
+			}
+			continue
+		}
+
+		t := q / p
+		if p < 0 {
+			if t > t1 {
+				return false
+			}
+			t0 = math.Max(t0, t)
+		} else {
+			if t < t0 {
+				return false
+			}
+			t1 = math.Min(t1, t)
+		}
+	}
+	return t0 <= t1
+}
+
+// closestOnRect returns the point on the boundary of the box
+// [minX, maxX]×[minY, maxY] closest to p and whether p lies inside.
+func closestOnRect(p Point, minX, minY, maxX, maxY float64) (Point, bool) {
+	c := Point{X: math.Max(minX, math.Min(p.X, maxX)), Y: math.Max(minY, math.Min(p.Y, maxY))}
+	if c != p {
+		return c, false
+	}
+
+	// p 在矩形内部，投影到最近的边上
+	left, right, bottom, top := p.X-minX, maxX-p.X, p.Y-minY, maxY-p.Y
+	switch math.Min(math.Min(left, right), math.Min(bottom, top)) {
+	case left:
+		c.X = minX
+	case right:
+		c.X = maxX
+	case bottom:
+		c.Y = minY
+	default:
+		c.Y = maxY
+	}
+	return c, true
+}
+
+// signedDistance returns the distance from p to the boundary point c,
+// negated when p lies inside the shape.
+func signedDistance(p, c Point, inside bool) float64 {
+	d := math.Hypot(p.X-c.X, p.Y-c.Y)
+	if inside {
+		return -d
+	}
+	return d
+}
+
+// closestOnSegment returns the point on the segment a-b closest to p.
+func closestOnSegment(p, a, b Point) Point {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	l2 := dx*dx + dy*dy
+	if l2 == 0 {
+		return a
+	}
+
+	t := ((p.X-a.X)*dx + (p.Y-a.Y)*dy) / l2
+	t = math.Max(0, math.Min(1, t))
+	return Point{X: a.X + t*dx, Y: a.Y + t*dy}
+}
+
+// cross returns the z component of (b-a)×(c-a).
+func cross(a, b, c Point) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
+
+// onSegment checks if p, known to be collinear with a-b, lies on it.
+func onSegment(p, a, b Point) bool {
+	return p.X >= math.Min(a.X, b.X) && p.X <= math.Max(a.X, b.X) &&
+		p.Y >= math.Min(a.Y, b.Y) && p.Y <= math.Max(a.Y, b.Y)
+}
+
+// segmentsIntersect checks if the segments a-b and c-d share at least one
+// point, including touching and collinear overlap.
+func segmentsIntersect(a, b, c, d Point) bool {
+	d1, d2 := cross(c, d, a), cross(c, d, b)
+	d3, d4 := cross(a, b, c), cross(a, b, d)
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+
+	return d1 == 0 && onSegment(a, c, d) ||
+		d2 == 0 && onSegment(b, c, d) ||
+		d3 == 0 && onSegment(c, a, b) ||
+		d4 == 0 && onSegment(d, a, b)
+}
+
+// segmentDistance returns the shortest distance between the segments a-b
+// and c-d.
+func segmentDistance(a, b, c, d Point) float64 {
+	if segmentsIntersect(a, b, c, d) {
+		return 0
+	}
+
+	dist := func(p, q Point) float64 { return math.Hypot(p.X-q.X, p.Y-q.Y) }
+	return math.Min(
+		math.Min(dist(a, closestOnSegment(a, c, d)), dist(b, closestOnSegment(b, c, d))),
+		math.Min(dist(c, closestOnSegment(c, a, b)), dist(d, closestOnSegment(d, a, b))),
+	)
+}
+
+// pointInPolygon checks if p lies inside the polygon using the even-odd
+// rule, which also handles concave polygons.
+func pointInPolygon(p Point, vertices []Point) bool {
+	inside := false
+	for i, j := 0, len(vertices)-1; i < len(vertices); j, i = i, i+1 {
+		a, b := vertices[i], vertices[j]
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// rotate rotates p around the origin by angle radians.
+func rotate(p Point, angle float64) Point {
+	sin, cos := math.Sincos(angle)
+	return Point{X: p.X*cos - p.Y*sin, Y: p.X*sin + p.Y*cos}
+}
@@ -0,0 +1,62 @@
+package rrt
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIntersectsSegment(t *testing.T) {
+	rect := NewRect(100, 100, 100, 100)
+	wall := NewRect(100, 0, 1, 1000)
+	circle := NewCircle(Point{X: 500, Y: 500}, 50)
+	box := NewOrientedBox(Point{X: 500, Y: 500}, 400, 10, math.Pi/4)
+	// 凹多边形，(300..500, 700..900) 是缺口
+	notched := NewPolygon(Point{X: 300, Y: 600}, Point{X: 600, Y: 600}, Point{X: 600, Y: 900},
+		Point{X: 500, Y: 900}, Point{X: 500, Y: 700}, Point{X: 300, Y: 700})
+
+	tests := []struct {
+		name      string
+		obs       Obstacle
+		p1, p2    Point
+		inflation float64
+		want      bool
+	}{
+		{"rect corner cut", rect, Point{X: 90, Y: 180}, Point{X: 120, Y: 210}, 0, true},
+		{"rect corner miss", rect, Point{X: 80, Y: 190}, Point{X: 110, Y: 220}, 0, false},
+		{"rect corner miss inflated", rect, Point{X: 80, Y: 190}, Point{X: 110, Y: 220}, 15, true},
+		{"rect segment inside", rect, Point{X: 120, Y: 120}, Point{X: 180, Y: 180}, 0, true},
+		{"rect point inside", rect, Point{X: 150, Y: 150}, Point{X: 150, Y: 150}, 0, true},
+		{"rect along edge", rect, Point{X: 50, Y: 200}, Point{X: 250, Y: 200}, 0, true},
+		{"rect endpoint on edge", rect, Point{X: 150, Y: 250}, Point{X: 150, Y: 200}, 0, true},
+		{"rect parallel outside", rect, Point{X: 50, Y: 201}, Point{X: 250, Y: 201}, 0, false},
+		{"thin wall crossed", wall, Point{X: 50, Y: 500}, Point{X: 150, Y: 500}, 0, true},
+		{"thin wall beside", wall, Point{X: 50, Y: 0}, Point{X: 50, Y: 1000}, 0, false},
+
+		{"circle chord", circle, Point{X: 400, Y: 500}, Point{X: 600, Y: 500}, 0, true},
+		{"circle segment inside", circle, Point{X: 490, Y: 500}, Point{X: 510, Y: 500}, 0, true},
+		{"circle tangent", circle, Point{X: 400, Y: 550}, Point{X: 600, Y: 550}, 0, true},
+		{"circle miss", circle, Point{X: 400, Y: 551}, Point{X: 600, Y: 551}, 0, false},
+		{"circle miss inflated", circle, Point{X: 400, Y: 551}, Point{X: 600, Y: 551}, 2, true},
+		{"circle segment ends short", circle, Point{X: 400, Y: 500}, Point{X: 440, Y: 500}, 0, false},
+
+		{"box crossed end to end", box, Point{X: 400, Y: 600}, Point{X: 600, Y: 400}, 0, true},
+		{"box segment inside", box, box.toWorld(Point{X: -150, Y: 2}), box.toWorld(Point{X: 150, Y: -2}), 0, true},
+		{"box corner cut", box, box.toWorld(Point{X: 190, Y: 8}), box.toWorld(Point{X: 205, Y: -7}), 0, true},
+		{"box parallel outside", box, box.toWorld(Point{X: -150, Y: 20}), box.toWorld(Point{X: 150, Y: 20}), 0, false},
+		{"box parallel inflated", box, box.toWorld(Point{X: -150, Y: 20}), box.toWorld(Point{X: 150, Y: 20}), 20, true},
+		{"box past the end", box, box.toWorld(Point{X: 210, Y: 0}), box.toWorld(Point{X: 250, Y: 0}), 0, false},
+
+		{"polygon inside notch", notched, Point{X: 350, Y: 750}, Point{X: 450, Y: 850}, 0, false},
+		{"polygon notch near edge inflated", notched, Point{X: 350, Y: 750}, Point{X: 450, Y: 750}, 60, true},
+		{"polygon from notch into arm", notched, Point{X: 350, Y: 800}, Point{X: 550, Y: 800}, 0, true},
+		{"polygon along notch edge", notched, Point{X: 400, Y: 700}, Point{X: 450, Y: 700}, 0, true},
+		{"polygon segment inside", notched, Point{X: 520, Y: 650}, Point{X: 580, Y: 850}, 0, true},
+		{"polygon crossed end to end", notched, Point{X: 200, Y: 650}, Point{X: 700, Y: 650}, 0, true},
+		{"polygon outside", notched, Point{X: 200, Y: 500}, Point{X: 700, Y: 500}, 0, false},
+	}
+	for _, tt := range tests {
+		if got := tt.obs.IntersectsSegment(tt.p1, tt.p2, tt.inflation); got != tt.want {
+			t.Errorf("%s: IntersectsSegment(%v, %v, %v) = %t, want %t", tt.name, tt.p1, tt.p2, tt.inflation, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package rrt
+
+import (
+	"math"
+)
+
+// ImprovedAPF is an APF extension strategy with a dynamic repulsion range,
+// velocity repulsion and an escape force for leaving local minima.
+type ImprovedAPF struct {
+	Kp        float64 // 引力增益系数
+	Krep      float64 // 斥力增益系数
+	P0        float64 // 斥力作用范围
+	Kv        float64 // 速度斥力系数
+	Speed     float64 // 机器人的名义速度，用于估计到达节点的时间，为 0 时按时间 0 计算
+	Clearance float64 // 安全距离，新节点离障碍物边界过近时会被推开
+	Nudge     float64 // 每次推离障碍物的距离
+
+	// Schedule 决定斥力作用范围随搜索进度的变化，为 nil 时使用 IterationSchedule(1000)
+	Schedule RangeSchedule
+}
+
+// NewImprovedAPF creates a new ImprovedAPF extension strategy.
+func NewImprovedAPF(kp, krep, p0 float64) *ImprovedAPF {
+	return &ImprovedAPF{
+		Kp:        kp,
+		Krep:      krep,
+		P0:        p0,
+		Kv:        0.5,
+		Clearance: 10,
+		Nudge:     5,
+	}
+}
+
+// Extend implements Extender.
+func (a *ImprovedAPF) Extend(r *RRT, nearestPoint, randomPoint Point) Point {
+	// 随机点与最近节点之间的距离
+	len := r.EuclideanDistance(nearestPoint, randomPoint)
+	if len == 0 {
+		return nearestPoint
+	}
+
+	// 斥力与速度斥力的合力方向
+	F := a.force(r, nearestPoint, r.Direction(nearestPoint, randomPoint))
+	if norm := math.Hypot(F.X, F.Y); norm != 0 {
+		F = Point{X: F.X / norm, Y: F.Y / norm}
+	}
+
+	// 随机点方向 + 目标引力 + 斥力
+	U := Point{
+		X: (randomPoint.X-nearestPoint.X)/len + a.Krep*F.X,
+		Y: (randomPoint.Y-nearestPoint.Y)/len + a.Krep*F.Y,
+	}
+	if len1 := r.EuclideanDistance(nearestPoint, r.Goal); len1 != 0 {
+		U.X += a.Kp * (r.Goal.X - nearestPoint.X) / len1
+		U.Y += a.Kp * (r.Goal.Y - nearestPoint.Y) / len1
+	}
+	normU := math.Hypot(U.X, U.Y)
+	if normU == 0 {
+		return nearestPoint
+	}
+
+	step := math.Min(r.Step, len)
+	newPoint := Point{
+		X: nearestPoint.X + step*U.X/normU,
+		Y: nearestPoint.Y + step*U.Y/normU,
+	}
+	return a.keepClear(r, newPoint)
+}
+
+// Escape implements Escaper. The escape direction is perpendicular to the
+// combined force acting on nearestPoint.
+func (a *ImprovedAPF) Escape(r *RRT, nearestPoint Point) (Point, bool) {
+	F := a.force(r, nearestPoint, Point{})
+	norm := math.Hypot(F.X, F.Y)
+	if norm == 0 {
+		return Point{}, false
+	}
+
+	escapePoint := Point{
+		X: nearestPoint.X - r.Step*F.Y/norm,
+		Y: nearestPoint.Y + r.Step*F.X/norm,
+	}
+	return a.keepClear(r, escapePoint), true
+}
+
+// timeAt returns the time the robot is expected to be at the node being
+// extended: the arrival time in ModeSpaceTime, the path cost driven at
+// Speed otherwise. Moving obstacles are taken at this time.
+func (a *ImprovedAPF) timeAt(r *RRT, p Point) float64 {
+	s := r.searchState(p)
+	if r.Mode != ModeSpaceTime && a.Speed > 0 {
+		return s.Cost / a.Speed
+	}
+	return s.Time
+}
+
+// force sums the repulsion and velocity repulsion of all obstacles acting
+// on p while the tree moves along heading (a unit vector, or zero).
+func (a *ImprovedAPF) force(r *RRT, p, heading Point) Point {
+	t := a.timeAt(r, p)
+	F := Point{}
+	for _, obs := range r.Obstacles {
+		var v Point
+		if m, ok := obs.(MovingObstacle); ok {
+			v = a.velocityRepulsion(r, p, heading, m.At(t), m.VelocityAt(t))
+			obs = m.At(t)
+		}
+		f := a.repulsion(r, p, obs)
+		F.X += f.X + v.X
+		F.Y += f.Y + v.Y
+	}
+	w := wallRepulsion(r, p, a.rangeAt(r, p))
+	F.X += w.X
+	F.Y += w.Y
+	return F
+}
+
+// repulsion computes the repulsive force of an obstacle with a range that
+// varies over the planning run.
+func (a *ImprovedAPF) repulsion(r *RRT, p Point, obs Obstacle) Point {
+	return repulsion(r, p, obs, a.rangeAt(r, p))
+}
+
+// rangeAt returns the repulsion range for extending from p, scaled by the
+// schedule.
+func (a *ImprovedAPF) rangeAt(r *RRT, p Point) float64 {
+	schedule := a.Schedule
+	if schedule == nil {
+		schedule = IterationSchedule(1000)
+	}
+
+	// 动态调整斥力作用范围
+	return a.P0 * schedule(r.searchState(p))
+}
+
+// velocityRepulsion computes the repulsion caused by an obstacle moving
+// with velocity v towards p. Only the part of the relative velocity that
+// closes the gap contributes, so obstacles moving away are ignored.
+func (a *ImprovedAPF) velocityRepulsion(r *RRT, p, heading Point, obs Obstacle, v Point) Point {
+	dist := obs.Distance(p, r.InfluenceRange)
+	if dist > a.P0 {
+		return Point{}
+	}
+
+	dir := r.Direction(obs.ClosestPoint(p, r.InfluenceRange), p)
+	if dist <= 0 {
+		dir = Point{X: -dir.X, Y: -dir.Y}
+	}
+	if math.IsNaN(dir.X) || math.IsNaN(dir.Y) {
+		return Point{}
+	}
+
+	// 障碍物相对机器人的速度在连线方向上的分量，正值表示正在靠近
+	approach := (v.X-a.Speed*heading.X)*dir.X + (v.Y-a.Speed*heading.Y)*dir.Y
+	if approach <= 0 {
+		return Point{}
+	}
+
+	f := a.Kv * approach / math.Max(dist, minRepulsionDistance)
+	return Point{X: f * dir.X, Y: f * dir.Y}
+}
+
+// keepClear pushes p away from the closest obstacle if it lies inside an
+// obstacle or closer than Clearance to its boundary. Moving obstacles are
+// taken at the same time as in force.
+func (a *ImprovedAPF) keepClear(r *RRT, p Point) Point {
+	t := a.timeAt(r, p)
+	var closest Obstacle
+	minDist := math.MaxFloat64
+	for _, obs := range r.Obstacles {
+		if m, ok := obs.(MovingObstacle); ok {
+			obs = m.At(t)
+		}
+		if dist := obs.Distance(p, r.InfluenceRange); dist < minDist {
+			minDist = dist
+			closest = obs
+		}
+	}
+	if closest == nil || minDist >= a.Clearance {
+		return p
+	}
+
+	dir := r.Direction(closest.ClosestPoint(p, r.InfluenceRange), p)
+	if minDist <= 0 {
+		dir = Point{X: -dir.X, Y: -dir.Y}
+	}
+	if math.IsNaN(dir.X) || math.IsNaN(dir.Y) {
+		return p
+	}
+	return Point{X: p.X + a.Nudge*dir.X, Y: p.Y + a.Nudge*dir.Y}
+}
@@ -0,0 +1,34 @@
+package rrt
+
+import (
+	"math"
+)
+
+// sampleEllipse returns a uniformly random point in the workspace part of
+// the ellipse with foci Start and Goal and major axis cBest. Only points
+// in it can lie on a path from Start to Goal shorter than cBest.
+func (r *RRT) sampleEllipse(cBest float64) Point {
+	cMin := r.EuclideanDistance(r.Start, r.Goal)
+	if cBest <= cMin {
+		return r.sampleSpace()
+	}
+
+	// 椭圆的半长轴、半短轴、中心和旋转角
+	a := cBest / 2
+	b := math.Sqrt(cBest*cBest-cMin*cMin) / 2
+	center := Point{X: (r.Start.X + r.Goal.X) / 2, Y: (r.Start.Y + r.Goal.Y) / 2}
+	angle := math.Atan2(r.Goal.Y-r.Start.Y, r.Goal.X-r.Start.X)
+
+	rng := r.Rand()
+	for {
+		// 单位圆内均匀采样后拉伸、旋转到椭圆上
+		rho := math.Sqrt(rng.Float64())
+		theta := 2 * math.Pi * rng.Float64()
+		p := rotate(Point{X: a * rho * math.Cos(theta), Y: b * rho * math.Sin(theta)}, angle)
+		p.X += center.X
+		p.Y += center.Y
+		if r.InBounds(p) {
+			return p
+		}
+	}
+}
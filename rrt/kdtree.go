@@ -0,0 +1,131 @@
+package rrt
+
+import (
+	"math"
+)
+
+// KDTree is an incremental 2-d tree used to find tree nodes near a point.
+// Points are never removed, which matches how the RRT tree grows.
+type KDTree struct {
+	nodes []kdNode
+}
+
+// kdNode is a point stored in the KDTree. Even depths split on X and odd
+// depths on Y.
+type kdNode struct {
+	p           Point
+	index       int // 节点在 RRT.PathV 中的索引
+	left, right int // 子节点在 nodes 中的位置，-1 表示没有
+}
+
+// NewKDTree creates an empty KDTree.
+func NewKDTree() *KDTree {
+	return &KDTree{}
+}
+
+// Len returns the number of points in the tree.
+func (t *KDTree) Len() int {
+	return len(t.nodes)
+}
+
+// Insert adds a point with the given index to the tree.
+func (t *KDTree) Insert(p Point, index int) {
+	t.nodes = append(t.nodes, kdNode{p: p, index: index, left: -1, right: -1})
+	n := len(t.nodes) - 1
+	if n == 0 {
+		return
+	}
+
+	cur, depth := 0, 0
+	for {
+		node := &t.nodes[cur]
+		next := &node.right
+		if kdLess(p, node.p, depth) {
+			next = &node.left
+		}
+		if *next == -1 {
+			*next = n
+			return
+		}
+		cur = *next
+		depth++
+	}
+}
+
+// Nearest returns the index and distance of the point closest to q. The
+// index is -1 if the tree is empty.
+func (t *KDTree) Nearest(q Point) (int, float64) {
+	best, bestDist := -1, math.MaxFloat64
+	if len(t.nodes) == 0 {
+		return best, bestDist
+	}
+
+	var search func(cur, depth int)
+	search = func(cur, depth int) {
+		if cur == -1 {
+			return
+		}
+		node := &t.nodes[cur]
+		if d := math.Hypot(q.X-node.p.X, q.Y-node.p.Y); d < bestDist || d == bestDist && node.index < best {
+			best, bestDist = node.index, d
+		}
+
+		near, far := node.right, node.left
+		if kdLess(q, node.p, depth) {
+			near, far = node.left, node.right
+		}
+		search(near, depth+1)
+		if kdDelta(q, node.p, depth) <= bestDist {
+			search(far, depth+1)
+		}
+	}
+	search(0, 0)
+	return best, bestDist
+}
+
+// Within returns the indices of all points within radius of q.
+func (t *KDTree) Within(q Point, radius float64) []int {
+	var indices []int
+	if len(t.nodes) == 0 {
+		return indices
+	}
+
+	stack := [][2]int{{0, 0}}
+	for len(stack) > 0 {
+		cur, depth := stack[len(stack)-1][0], stack[len(stack)-1][1]
+		stack = stack[:len(stack)-1]
+
+		node := &t.nodes[cur]
+		if math.Hypot(q.X-node.p.X, q.Y-node.p.Y) <= radius {
+			indices = append(indices, node.index)
+		}
+
+		// 只有与 q 所在一侧相对的子树需要用半径剪枝
+		left, crosses := kdLess(q, node.p, depth), kdDelta(q, node.p, depth) <= radius
+		if node.left != -1 && (left || crosses) {
+			stack = append(stack, [2]int{node.left, depth + 1})
+		}
+		if node.right != -1 && (!left || crosses) {
+			stack = append(stack, [2]int{node.right, depth + 1})
+		}
+	}
+	return indices
+}
+
+// kdLess reports whether p falls on the left side of the splitting plane
+// through s at the given depth.
+func kdLess(p, s Point, depth int) bool {
+	if depth%2 == 0 {
+		return p.X < s.X
+	}
+	return p.Y < s.Y
+}
+
+// kdDelta returns the distance from p to the splitting plane through s at
+// the given depth.
+func kdDelta(p, s Point, depth int) float64 {
+	if depth%2 == 0 {
+		return math.Abs(p.X - s.X)
+	}
+	return math.Abs(p.Y - s.Y)
+}
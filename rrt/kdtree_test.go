@@ -0,0 +1,111 @@
+package rrt
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// randomPoints returns n points in [0, 1000)², rounded to a coarse grid so
+// that duplicates and points on splitting planes occur.
+func randomPoints(rng *rand.Rand, n int, grid float64) []Point {
+	points := make([]Point, n)
+	for i := range points {
+		points[i] = Point{
+			X: math.Floor(rng.Float64()*1000/grid) * grid,
+			Y: math.Floor(rng.Float64()*1000/grid) * grid,
+		}
+	}
+	return points
+}
+
+func buildKDTree(points []Point) *KDTree {
+	t := NewKDTree()
+	for i, p := range points {
+		t.Insert(p, i)
+	}
+	return t
+}
+
+// linearNearest is the linear scan the KDTree replaces. Ties go to the
+// smaller index, like KDTree.Nearest.
+func linearNearest(points []Point, q Point) (int, float64) {
+	best, bestDist := -1, math.MaxFloat64
+	for i, p := range points {
+		if d := math.Hypot(q.X-p.X, q.Y-p.Y); d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best, bestDist
+}
+
+func linearWithin(points []Point, q Point, radius float64) []int {
+	var indices []int
+	for i, p := range points {
+		if math.Hypot(q.X-p.X, q.Y-p.Y) <= radius {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+func TestKDTreeMatchesLinearScan(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, grid := range []float64{1e-9, 10, 100} {
+		for _, n := range []int{0, 1, 2, 10, 500} {
+			points := randomPoints(rng, n, grid)
+			// 重复插入一部分点
+			for i := 0; i < n/5; i++ {
+				points = append(points, points[rng.Intn(n)])
+			}
+			tree := buildKDTree(points)
+			if tree.Len() != len(points) {
+				t.Fatalf("grid %v, n %d: Len() = %d, want %d", grid, n, tree.Len(), len(points))
+			}
+
+			for k := 0; k < 200; k++ {
+				q := randomPoints(rng, 1, grid)[0]
+				if k%2 == 1 {
+					q.X += rng.Float64() * grid
+				}
+
+				gotIndex, gotDist := tree.Nearest(q)
+				wantIndex, wantDist := linearNearest(points, q)
+				if gotIndex != wantIndex || gotDist != wantDist {
+					t.Fatalf("grid %v, n %d: Nearest(%v) = %d, %v; want %d, %v",
+						grid, n, q, gotIndex, gotDist, wantIndex, wantDist)
+				}
+
+				radius := rng.Float64() * 150
+				got := tree.Within(q, radius)
+				slices.Sort(got)
+				want := linearWithin(points, q, radius)
+				if !slices.Equal(got, want) {
+					t.Fatalf("grid %v, n %d: Within(%v, %v) = %v, want %v", grid, n, q, radius, got, want)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkNearest(b *testing.B) {
+	for _, n := range []int{1000, 5000, 100000} {
+		rng := rand.New(rand.NewSource(1))
+		points := randomPoints(rng, n, 1e-9)
+		queries := randomPoints(rng, 1024, 1e-9)
+		tree := buildKDTree(points)
+
+		b.Run(fmt.Sprintf("kdtree/n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				tree.Nearest(queries[i%len(queries)])
+			}
+		})
+		b.Run(fmt.Sprintf("linear/n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				linearNearest(points, queries[i%len(queries)])
+			}
+		})
+	}
+}
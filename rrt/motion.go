@@ -0,0 +1,246 @@
+package rrt
+
+import (
+	"sort"
+)
+
+// Motion describes how an obstacle moves over time. Offsets are relative
+// to the position the obstacle shape was defined at.
+type Motion interface {
+	// Offset returns the displacement of the obstacle at time t.
+	Offset(t float64) Point
+	// Velocity returns the velocity of the obstacle at time t.
+	Velocity(t float64) Point
+	// Knots returns the times in (t1, t2), in ascending order, at which
+	// the velocity changes. Between knots the motion must be linear.
+	Knots(t1, t2 float64) []float64
+}
+
+// LinearMotion moves an obstacle with a constant velocity.
+type LinearMotion struct {
+	V Point // 速度
+}
+
+// Offset implements Motion.
+func (m LinearMotion) Offset(t float64) Point {
+	return Point{X: m.V.X * t, Y: m.V.Y * t}
+}
+
+// Velocity implements Motion.
+func (m LinearMotion) Velocity(float64) Point {
+	return m.V
+}
+
+// Knots implements Motion. A linear motion has none.
+func (m LinearMotion) Knots(float64, float64) []float64 {
+	return nil
+}
+
+// Waypoint is a displacement an obstacle reaches at time T.
+type Waypoint struct {
+	T      float64
+	Offset Point
+}
+
+// Trajectory moves an obstacle through waypoints, interpolating linearly
+// between them. The obstacle rests at the first waypoint before it and at
+// the last one after it.
+type Trajectory struct {
+	Waypoints []Waypoint // 按时间升序排列
+}
+
+// NewTrajectory creates a Trajectory, sorting the waypoints by time.
+func NewTrajectory(waypoints ...Waypoint) *Trajectory {
+	sort.SliceStable(waypoints, func(i, j int) bool { return waypoints[i].T < waypoints[j].T })
+	return &Trajectory{
+		Waypoints: waypoints,
+	}
+}
+
+// segment returns the index i such that t lies in [W[i].T, W[i+1].T), or
+// -1 before the first and len(W)-1 after the last waypoint.
+func (m *Trajectory) segment(t float64) int {
+	return sort.Search(len(m.Waypoints), func(i int) bool { return m.Waypoints[i].T > t }) - 1
+}
+
+// Offset implements Motion.
+func (m *Trajectory) Offset(t float64) Point {
+	w := m.Waypoints
+	if len(w) == 0 {
+		return Point{}
+	}
+
+	i := m.segment(t)
+	if i < 0 {
+		return w[0].Offset
+	}
+	if i >= len(w)-1 {
+		return w[len(w)-1].Offset
+	}
+	s := (t - w[i].T) / (w[i+1].T - w[i].T)
+	return Point{
+		X: w[i].Offset.X + s*(w[i+1].Offset.X-w[i].Offset.X),
+		Y: w[i].Offset.Y + s*(w[i+1].Offset.Y-w[i].Offset.Y),
+	}
+}
+
+// Velocity implements Motion.
+func (m *Trajectory) Velocity(t float64) Point {
+	w := m.Waypoints
+	i := m.segment(t)
+	if i < 0 || i >= len(w)-1 {
+		return Point{}
+	}
+	dt := w[i+1].T - w[i].T
+	return Point{
+		X: (w[i+1].Offset.X - w[i].Offset.X) / dt,
+		Y: (w[i+1].Offset.Y - w[i].Offset.Y) / dt,
+	}
+}
+
+// Knots implements Motion. They are the waypoint times.
+func (m *Trajectory) Knots(t1, t2 float64) []float64 {
+	var knots []float64
+	for _, w := range m.Waypoints {
+		if w.T > t1 && w.T < t2 {
+			knots = append(knots, w.T)
+		}
+	}
+	return knots
+}
+
+// MovingObstacle is an Obstacle whose position changes over time.
+type MovingObstacle interface {
+	Obstacle
+	// At returns the obstacle at time t.
+	At(t float64) Obstacle
+	// VelocityAt returns the velocity of the obstacle at time t.
+	VelocityAt(t float64) Point
+	// IntersectsMotion checks if a point moving in a straight line from p1
+	// at time t1 to p2 at time t2 comes within influenceRange of the
+	// obstacle.
+	IntersectsMotion(p1, p2 Point, t1, t2, influenceRange float64) bool
+}
+
+// Moving is an obstacle whose shape is displaced over time by a Motion.
+// Used as a plain Obstacle it is the shape at time 0.
+type Moving struct {
+	Shape  Obstacle
+	Motion Motion
+}
+
+// NewMoving creates a new Moving instance.
+func NewMoving(shape Obstacle, motion Motion) *Moving {
+	return &Moving{
+		Shape:  shape,
+		Motion: motion,
+	}
+}
+
+// At returns the obstacle at time t.
+func (m *Moving) At(t float64) Obstacle {
+	return translated{shape: m.Shape, offset: m.Motion.Offset(t)}
+}
+
+// VelocityAt returns the velocity of the obstacle at time t.
+func (m *Moving) VelocityAt(t float64) Point {
+	return m.Motion.Velocity(t)
+}
+
+// IntersectsMotion implements MovingObstacle. Between two knots both the
+// point and the obstacle move linearly, so relative to the shape the point
+// moves along a straight segment and the check is exact.
+func (m *Moving) IntersectsMotion(p1, p2 Point, t1, t2, influenceRange float64) bool {
+	at := func(t float64) Point {
+		s := 0.0
+		if t2 > t1 {
+			s = (t - t1) / (t2 - t1)
+		}
+		o := m.Motion.Offset(t)
+		return Point{
+			X: p1.X + s*(p2.X-p1.X) - o.X,
+			Y: p1.Y + s*(p2.Y-p1.Y) - o.Y,
+		}
+	}
+
+	times := append(append([]float64{t1}, m.Motion.Knots(t1, t2)...), t2)
+	for i := 1; i < len(times); i++ {
+		if m.Shape.IntersectsSegment(at(times[i-1]), at(times[i]), influenceRange) {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains checks if a point (x, y) is inside the obstacle at time 0.
+func (m *Moving) Contains(x, y float64) bool {
+	return m.At(0).Contains(x, y)
+}
+
+// IntersectsSegment implements Obstacle at time 0.
+func (m *Moving) IntersectsSegment(p1, p2 Point, influenceRange float64) bool {
+	return m.At(0).IntersectsSegment(p1, p2, influenceRange)
+}
+
+// ClosestPoint implements Obstacle at time 0.
+func (m *Moving) ClosestPoint(p Point, influenceRange float64) Point {
+	return m.At(0).ClosestPoint(p, influenceRange)
+}
+
+// Distance implements Obstacle at time 0.
+func (m *Moving) Distance(p Point, influenceRange float64) float64 {
+	return m.At(0).Distance(p, influenceRange)
+}
+
+// GetBounds implements Obstacle at time 0.
+func (m *Moving) GetBounds(influenceRange float64) (float64, float64, float64, float64) {
+	return m.At(0).GetBounds(influenceRange)
+}
+
+// Outline implements Obstacle at time 0.
+func (m *Moving) Outline() []Point {
+	return m.At(0).Outline()
+}
+
+// translated is an obstacle shape shifted by an offset.
+type translated struct {
+	shape  Obstacle
+	offset Point
+}
+
+func (o translated) local(p Point) Point {
+	return Point{X: p.X - o.offset.X, Y: p.Y - o.offset.Y}
+}
+
+func (o translated) world(p Point) Point {
+	return Point{X: p.X + o.offset.X, Y: p.Y + o.offset.Y}
+}
+
+func (o translated) Contains(x, y float64) bool {
+	return o.shape.Contains(x-o.offset.X, y-o.offset.Y)
+}
+
+func (o translated) IntersectsSegment(p1, p2 Point, influenceRange float64) bool {
+	return o.shape.IntersectsSegment(o.local(p1), o.local(p2), influenceRange)
+}
+
+func (o translated) ClosestPoint(p Point, influenceRange float64) Point {
+	return o.world(o.shape.ClosestPoint(o.local(p), influenceRange))
+}
+
+func (o translated) Distance(p Point, influenceRange float64) float64 {
+	return o.shape.Distance(o.local(p), influenceRange)
+}
+
+func (o translated) GetBounds(influenceRange float64) (float64, float64, float64, float64) {
+	x, y, w, h := o.shape.GetBounds(influenceRange)
+	return x + o.offset.X, y + o.offset.Y, w, h
+}
+
+func (o translated) Outline() []Point {
+	pts := o.shape.Outline()
+	for i := range pts {
+		pts[i] = o.world(pts[i])
+	}
+	return pts
+}
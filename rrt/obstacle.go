@@ -1,13 +1,36 @@
 package rrt
 
-// Obstacle represents a rectangular obstacle in the 2D space.
-type Obstacle struct {
+// Obstacle is a region of the 2D space that paths must not enter.
+//
+// Every method that takes an influence range works on the obstacle grown
+// by that distance, which is how RRT keeps a safety margin around it.
+type Obstacle interface {
+	// Contains checks if a point (x, y) is inside the obstacle.
+	Contains(x, y float64) bool
+	// IntersectsSegment checks if the segment p1-p2 touches the inflated
+	// obstacle, including segments that lie entirely inside it.
+	IntersectsSegment(p1, p2 Point, influenceRange float64) bool
+	// ClosestPoint returns the point on the inflated boundary closest to p.
+	ClosestPoint(p Point, influenceRange float64) Point
+	// Distance returns the distance from p to the inflated boundary. It is
+	// negative when p lies inside.
+	Distance(p Point, influenceRange float64) float64
+	// GetBounds returns the axis-aligned bounding box of the inflated
+	// obstacle as x, y, width, height.
+	GetBounds(influenceRange float64) (float64, float64, float64, float64)
+	// Outline returns the boundary as a closed polygon for plotting.
+	Outline() []Point
+}
+
+// Rect represents an axis-aligned rectangular obstacle. It is inflated by
+// growing each side, so the corners stay square.
+type Rect struct {
 	X, Y, Width, Height float64
 }
 
-// NewObstacle creates a new Obstacle instance.
-func NewObstacle(x, y, width, height float64) *Obstacle {
-	return &Obstacle{
+// NewRect creates a new Rect instance.
+func NewRect(x, y, width, height float64) *Rect {
+	return &Rect{
 		X:      x,
 		Y:      y,
 		Width:  width,
@@ -15,12 +38,50 @@ func NewObstacle(x, y, width, height float64) *Obstacle {
 	}
 }
 
+// NewObstacle creates a new rectangular obstacle.
+//
+// Deprecated: use NewRect.
+func NewObstacle(x, y, width, height float64) *Rect {
+	return NewRect(x, y, width, height)
+}
+
 // Contains checks if a point (x, y) is inside the obstacle.
-func (o *Obstacle) Contains(x, y float64) bool {
+func (o *Rect) Contains(x, y float64) bool {
 	return x >= o.X && x <= o.X+o.Width && y >= o.Y && y <= o.Y+o.Height
 }
 
+// IntersectsSegment implements Obstacle.
+func (o *Rect) IntersectsSegment(p1, p2 Point, influenceRange float64) bool {
+	ox, oy, ow, oh := o.GetBounds(influenceRange)
+	return segmentIntersectsRect(p1, p2, ox, oy, ox+ow, oy+oh)
+}
+
+// ClosestPoint implements Obstacle.
+func (o *Rect) ClosestPoint(p Point, influenceRange float64) Point {
+	ox, oy, ow, oh := o.GetBounds(influenceRange)
+	c, _ := closestOnRect(p, ox, oy, ox+ow, oy+oh)
+	return c
+}
+
+// Distance implements Obstacle.
+func (o *Rect) Distance(p Point, influenceRange float64) float64 {
+	ox, oy, ow, oh := o.GetBounds(influenceRange)
+	c, inside := closestOnRect(p, ox, oy, ox+ow, oy+oh)
+	return signedDistance(p, c, inside)
+}
+
 // GetBounds returns the bounds of the obstacle with an influence range.
-func (o *Obstacle) GetBounds(influenceRange float64) (float64, float64, float64, float64) {
+func (o *Rect) GetBounds(influenceRange float64) (float64, float64, float64, float64) {
 	return o.X - influenceRange, o.Y - influenceRange, o.Width + 2*influenceRange, o.Height + 2*influenceRange
 }
+
+// Outline implements Obstacle.
+func (o *Rect) Outline() []Point {
+	return []Point{
+		{X: o.X, Y: o.Y},
+		{X: o.X + o.Width, Y: o.Y},
+		{X: o.X + o.Width, Y: o.Y + o.Height},
+		{X: o.X, Y: o.Y + o.Height},
+		{X: o.X, Y: o.Y},
+	}
+}
@@ -0,0 +1,84 @@
+package rrt
+
+import (
+	"math"
+)
+
+// OrientedBox represents a rectangle rotated by Angle radians around its
+// centre, e.g. a diagonal wall. Like Rect it is inflated by growing each
+// side.
+type OrientedBox struct {
+	Center                Point
+	HalfWidth, HalfHeight float64
+	Angle                 float64
+}
+
+// NewOrientedBox creates a new OrientedBox instance.
+func NewOrientedBox(center Point, width, height, angle float64) *OrientedBox {
+	return &OrientedBox{
+		Center:     center,
+		HalfWidth:  width / 2,
+		HalfHeight: height / 2,
+		Angle:      angle,
+	}
+}
+
+// toLocal converts p into the box frame, where the box is axis-aligned and
+// centred on the origin.
+func (b *OrientedBox) toLocal(p Point) Point {
+	return rotate(Point{X: p.X - b.Center.X, Y: p.Y - b.Center.Y}, -b.Angle)
+}
+
+// toWorld converts p from the box frame back to world coordinates.
+func (b *OrientedBox) toWorld(p Point) Point {
+	q := rotate(p, b.Angle)
+	return Point{X: q.X + b.Center.X, Y: q.Y + b.Center.Y}
+}
+
+// Contains checks if a point (x, y) is inside the obstacle.
+func (b *OrientedBox) Contains(x, y float64) bool {
+	p := b.toLocal(Point{X: x, Y: y})
+	return math.Abs(p.X) <= b.HalfWidth && math.Abs(p.Y) <= b.HalfHeight
+}
+
+// IntersectsSegment implements Obstacle.
+func (b *OrientedBox) IntersectsSegment(p1, p2 Point, influenceRange float64) bool {
+	hw, hh := b.HalfWidth+influenceRange, b.HalfHeight+influenceRange
+	return segmentIntersectsRect(b.toLocal(p1), b.toLocal(p2), -hw, -hh, hw, hh)
+}
+
+// ClosestPoint implements Obstacle.
+func (b *OrientedBox) ClosestPoint(p Point, influenceRange float64) Point {
+	hw, hh := b.HalfWidth+influenceRange, b.HalfHeight+influenceRange
+	c, _ := closestOnRect(b.toLocal(p), -hw, -hh, hw, hh)
+	return b.toWorld(c)
+}
+
+// Distance implements Obstacle.
+func (b *OrientedBox) Distance(p Point, influenceRange float64) float64 {
+	hw, hh := b.HalfWidth+influenceRange, b.HalfHeight+influenceRange
+	local := b.toLocal(p)
+	c, inside := closestOnRect(local, -hw, -hh, hw, hh)
+	return signedDistance(local, c, inside)
+}
+
+// GetBounds implements Obstacle.
+func (b *OrientedBox) GetBounds(influenceRange float64) (float64, float64, float64, float64) {
+	sin, cos := math.Sincos(b.Angle)
+	hw, hh := b.HalfWidth+influenceRange, b.HalfHeight+influenceRange
+	ex := math.Abs(hw*cos) + math.Abs(hh*sin)
+	ey := math.Abs(hw*sin) + math.Abs(hh*cos)
+	return b.Center.X - ex, b.Center.Y - ey, 2 * ex, 2 * ey
+}
+
+// Outline implements Obstacle.
+func (b *OrientedBox) Outline() []Point {
+	hw, hh := b.HalfWidth, b.HalfHeight
+	return []Point{
+		b.toWorld(Point{X: -hw, Y: -hh}),
+		b.toWorld(Point{X: hw, Y: -hh}),
+		b.toWorld(Point{X: hw, Y: hh}),
+		b.toWorld(Point{X: -hw, Y: hh}),
+		b.toWorld(Point{X: -hw, Y: -hh}),
+	}
+}
@@ -0,0 +1,284 @@
+package rrt
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Result describes the outcome of a planning run.
+type Result struct {
+	Success    bool      // 是否找到到达目标的路径
+	Path       []Point   // 从起点到目标的路径
+	Cost       float64   // 路径长度
+	Times      []float64 // 空间-时间模式下到达路径上每个点的时间
+	Iterations int       // 实际使用的迭代次数
+	Nodes      int       // 树中的节点数
+	Escapes    int       // 通过逃逸力摆脱局部极小值的次数
+	Seed       int64     // 本次规划使用的随机数种子
+
+	Waypoints       int           // 路径上的点数
+	CollisionChecks int           // 边的碰撞检测次数
+	WallTime        time.Duration // 规划耗时
+
+	Convergence []CostSample  // 最优路径代价随迭代次数的变化，只在代价下降时记录
+	Trace       []TraceSample // 每 TraceEvery 次迭代及结束时的搜索进度，TraceEvery 为 0 时为空
+}
+
+// CostSample is the best path cost at an iteration.
+type CostSample struct {
+	Iteration int
+	Cost      float64
+}
+
+// Plan runs the search from Start towards Goal and returns the result.
+// It is PlanContext without a context.
+func (r *RRT) Plan() Result {
+	result, _ := r.PlanContext(context.Background())
+	return result
+}
+
+// PlanContext runs the search from Start towards Goal and returns the
+// result. The tree is reset and the random source reseeded from Seed
+// before planning, so repeated calls with the same Seed give the same
+// result as long as the search is not cut short by time.
+//
+// In ModeRRT the search stops at the first node within Bias of the goal.
+// In ModeRRTStar all NumNodes iterations are used and the cheapest node
+// within Bias of the goal is returned. ModeInformed is ModeRRTStar that
+// samples only the ellipse that can still shorten the best path once one
+// is found. ModeSpaceTime stops like ModeRRT but checks every edge against
+// the moving obstacles over the time it is driven at MaxSpeed, and returns
+// the arrival times along the path.
+// ModeConnect grows a second tree from the goal and stops as soon as the
+// two trees meet; the path then ends exactly at Goal.
+//
+// With Anytime set ModeRRT and ModeSpaceTime also keep searching after the
+// first solution. The search ends after NumNodes iterations, when
+// TimeBudget has passed or when ctx is done, whichever comes first, and
+// the best path found so far is returned. NumNodes <= 0 removes the
+// iteration cap if TimeBudget is set. Running out of TimeBudget is a
+// normal end. If ctx is canceled or its deadline passes, ErrCanceled or
+// ErrTimeout is returned together with the best path found so far; the
+// partial tree stays in PathV.
+//
+// With TraceEvery set the iteration count, elapsed time, tree size and
+// best cost are sampled before the first iteration, every TraceEvery
+// iterations and at the end of the search into Result.Trace.
+func (r *RRT) PlanContext(ctx context.Context) (Result, error) {
+	start := time.Now()
+	r.reset()
+	r.started = start
+
+	var result Result
+	var err error
+	if r.Mode == ModeConnect {
+		result, err = r.planConnect(ctx)
+	} else {
+		result, err = r.plan(ctx)
+	}
+
+	result.Waypoints = len(result.Path)
+	result.CollisionChecks = r.checks
+	if r.goalTree != nil {
+		result.CollisionChecks += r.goalTree.checks
+	}
+	result.WallTime = time.Since(start)
+	result.Trace = r.trace
+	return result, err
+}
+
+// plan is the single-tree search loop of PlanContext.
+func (r *RRT) plan(ctx context.Context) (Result, error) {
+	star := r.Mode == ModeRRTStar || r.Mode == ModeInformed
+	var goalNodes []int
+	var convergence []CostSample
+	var stopErr error
+	iterations, escapes := 0, 0
+	limit := r.newBudget(ctx)
+	for {
+		r.record(iterations, bestCost(convergence), false)
+		more, err := limit.next(iterations)
+		if !more {
+			stopErr = err
+			break
+		}
+		iterations++
+		r.iteration = iterations
+
+		randomPoint := r.Sample()
+		nearestPoint, nearestIndex := r.NearestPoint(randomPoint)
+		r.extending = nearestIndex
+		newPoint := r.extend(nearestPoint, randomPoint)
+		if r.inTree(newPoint) {
+			// 采样点与已有节点重合（例如目标已在树中），或扩展策略没有前进
+			continue
+		}
+
+		arrival, ok := r.connect(nearestIndex, newPoint)
+		if !ok {
+			escapePoint, found := r.escape(nearestPoint)
+			if !found || r.inTree(escapePoint) {
+				continue
+			}
+			if arrival, ok = r.connect(nearestIndex, escapePoint); !ok {
+				continue
+			}
+			newPoint = escapePoint
+			escapes++
+		}
+
+		parent := nearestIndex
+		var neighbors []int
+		if star {
+			neighbors = r.Near(newPoint, r.NeighborRadius())
+			parent = r.chooseParent(newPoint, nearestIndex, neighbors)
+		}
+		index := r.addNode(newPoint, parent)
+		if r.Times != nil {
+			r.Times = append(r.Times, arrival)
+		}
+		if star {
+			r.rewire(index, neighbors)
+		}
+
+		if r.EuclideanDistance(newPoint, r.Goal) <= r.Bias {
+			goalNodes = append(goalNodes, index)
+		}
+
+		// 重连可能降低已有目标节点的代价，所以每次迭代都重新取最优值
+		if best := r.bestGoal(goalNodes); best != -1 {
+			n := len(convergence)
+			if convergence = recordCost(convergence, iterations, r.Cost[best]); len(convergence) > n {
+				r.improve(iterations, best)
+			}
+			if !star && !r.Anytime {
+				break
+			}
+		}
+	}
+	r.record(iterations, bestCost(convergence), true)
+	goalIndex := r.bestGoal(goalNodes)
+
+	result := Result{
+		Iterations: iterations,
+		Nodes:      len(r.PathV),
+		Escapes:    escapes,
+		Seed:       r.Seed,
+
+		Convergence: convergence,
+	}
+	if goalIndex != -1 {
+		r.ExtractPath(goalIndex)
+		result.Success = true
+		result.Path = r.Path
+		result.Cost = r.Cost[goalIndex]
+		result.Times = r.pathTimes(goalIndex)
+	}
+	return result, stopErr
+}
+
+// improve is called whenever the best goal node gets cheaper.
+func (r *RRT) improve(iteration, best int) {
+	if r.Mode == ModeInformed {
+		r.ellipse = r.Cost[best] + r.Bias
+	}
+	if r.OnImprove != nil {
+		r.OnImprove(iteration, r.Cost[best], r.pathTo(best))
+	}
+}
+
+// recordCost appends a sample to the convergence curve if cost improves on
+// the last one.
+func recordCost(curve []CostSample, iteration int, cost float64) []CostSample {
+	if n := len(curve); n > 0 && curve[n-1].Cost <= cost {
+		return curve
+	}
+	return append(curve, CostSample{Iteration: iteration, Cost: cost})
+}
+
+// Sample returns a random point in the workspace, or the goal with
+// probability GoalProb. After ModeInformed found a path the point is drawn
+// from the informed ellipse instead of the whole workspace.
+func (r *RRT) Sample() Point {
+	rng := r.Rand()
+	if rng.Float64() <= r.GoalProb {
+		return r.Goal
+	}
+	if r.ellipse > 0 {
+		return r.sampleEllipse(r.ellipse)
+	}
+	return r.sampleSpace()
+}
+
+// sampleSpace returns a uniformly random point in the workspace.
+func (r *RRT) sampleSpace() Point {
+	rng := r.Rand()
+	return Point{
+		X: r.XMin + rng.Float64()*(r.XMax-r.XMin),
+		Y: r.YMin + rng.Float64()*(r.YMax-r.YMin),
+	}
+}
+
+// Rand returns the random source of the current run. Plan reseeds it from
+// Seed, so strategies that draw from it stay reproducible.
+func (r *RRT) Rand() *rand.Rand {
+	if r.rng == nil {
+		r.rng = rand.New(rand.NewSource(r.Seed))
+	}
+	return r.rng
+}
+
+// PathLength returns the total length of a path.
+func PathLength(path []Point) float64 {
+	length := 0.0
+	for i := 1; i < len(path); i++ {
+		length += math.Hypot(path[i].X-path[i-1].X, path[i].Y-path[i-1].Y)
+	}
+	return length
+}
+
+// reset clears the tree so that it only contains the start point.
+func (r *RRT) reset() {
+	r.PathV = []Point{r.Start}
+	r.Parent = []int{-1}
+	r.Cost = []float64{0}
+	r.Times = nil
+	if r.Mode == ModeSpaceTime {
+		r.Times = []float64{0}
+	}
+	r.children = [][]int{nil}
+	r.index = nil
+	r.goalTree = nil
+	r.PathE = [][2]Point{}
+	r.Path = []Point{}
+	r.iteration = 0
+	r.extending = 0
+	r.checks = 0
+	r.ellipse = 0
+	r.trace = nil
+	r.rng = rand.New(rand.NewSource(r.Seed))
+}
+
+// inTree reports whether the tree already has a node at p. Such a point
+// would only add a zero-length edge.
+func (r *RRT) inTree(p Point) bool {
+	_, dist := r.spatialIndex().Nearest(p)
+	return dist == 0
+}
+
+// addNode appends a point to the tree under the given parent and returns
+// its index. PathE[i-1] always holds the edge leading to node i.
+func (r *RRT) addNode(p Point, parent int) int {
+	r.PathV = append(r.PathV, p)
+	r.Parent = append(r.Parent, parent)
+	r.Cost = append(r.Cost, r.Cost[parent]+r.EuclideanDistance(r.PathV[parent], p))
+	r.PathE = append(r.PathE, [2]Point{r.PathV[parent], p})
+	r.children = append(r.children, nil)
+
+	index := len(r.PathV) - 1
+	r.children[parent] = append(r.children[parent], index)
+	r.spatialIndex()
+	return index
+}
@@ -0,0 +1,104 @@
+package rrt
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// shapesProblem builds a planner on the map of scenarios/shapes.yaml with
+// a moving obstacle added, steered by the improved APF.
+func shapesProblem(mode Mode, seed int64) *RRT {
+	obstacles := []Obstacle{
+		NewCircle(Point{X: 300, Y: 300}, 120),
+		NewOrientedBox(Point{X: 650, Y: 350}, 400, 40, 45*math.Pi/180),
+		NewPolygon(Point{X: 300, Y: 600}, Point{X: 600, Y: 600}, Point{X: 600, Y: 900},
+			Point{X: 500, Y: 900}, Point{X: 500, Y: 700}, Point{X: 300, Y: 700}),
+		NewRect(750, 600, 100, 250),
+		NewMoving(NewCircle(Point{X: 900, Y: 200}, 40), LinearMotion{V: Point{X: -2, Y: 1}}),
+	}
+	r := NewRRT(Point{X: 0, Y: 0}, Point{X: 999, Y: 999}, 20, 20, 1500, 1000, 1000, obstacles, 10)
+	r.Mode = mode
+	r.Extender = NewImprovedAPF(1, 0.5, 50)
+	r.Seed = seed
+	r.MaxSpeed = 10
+	r.MaxWait = 20
+	return r
+}
+
+func TestPlanIsReproducible(t *testing.T) {
+	for mode := range modeNames {
+		for seed := int64(1); seed <= 3; seed++ {
+			a, b := shapesProblem(mode, seed), shapesProblem(mode, seed)
+			ra, rb := a.Plan(), b.Plan()
+
+			if !slices.Equal(a.PathV, b.PathV) {
+				t.Errorf("%v, seed %d: trees differ (%d and %d nodes)", mode, seed, len(a.PathV), len(b.PathV))
+			}
+			if !slices.Equal(a.Parent, b.Parent) {
+				t.Errorf("%v, seed %d: parents differ", mode, seed)
+			}
+			if !slices.Equal(ra.Path, rb.Path) || ra.Cost != rb.Cost || ra.Success != rb.Success {
+				t.Errorf("%v, seed %d: paths differ: cost %v and %v", mode, seed, ra.Cost, rb.Cost)
+			}
+			if !slices.Equal(ra.Times, rb.Times) {
+				t.Errorf("%v, seed %d: arrival times differ", mode, seed)
+			}
+
+			// 重复规划会重置树，结果也必须相同
+			rc := a.Plan()
+			if !slices.Equal(a.PathV, b.PathV) || !slices.Equal(rc.Path, rb.Path) {
+				t.Errorf("%v, seed %d: planning again with the same planner gives a different result", mode, seed)
+			}
+		}
+	}
+}
+
+// fiveRectsProblem builds a planner on the map of scenarios/five_rects.yaml.
+func fiveRectsProblem(mode Mode, extender Extender) *RRT {
+	obstacles := []Obstacle{
+		NewRect(150, 150, 150, 150),
+		NewRect(600, 200, 100, 100),
+		NewRect(200, 600, 100, 100),
+		NewRect(700, 700, 150, 150),
+		NewRect(400, 400, 200, 200),
+	}
+	r := NewRRT(Point{X: 0, Y: 0}, Point{X: 999, Y: 999}, 20, 20, 3000, 1000, 1000, obstacles, 10)
+	r.Mode = mode
+	r.Extender = extender
+	r.Seed = 7
+	return r
+}
+
+func TestTreeHasNoDuplicatePoints(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     Mode
+		anytime  bool
+		extender Extender
+	}{
+		{"rrtstar", ModeRRTStar, false, nil},
+		{"informed", ModeInformed, false, nil},
+		{"rrtstar+apf", ModeRRTStar, false, NewAPF(1, 0.5, 50)},
+		{"rrtstar+improved-apf", ModeRRTStar, false, NewImprovedAPF(1, 0.5, 50)},
+		{"rrt anytime", ModeRRT, true, nil},
+		{"spacetime anytime", ModeSpaceTime, true, nil},
+	}
+	for _, tt := range tests {
+		r := fiveRectsProblem(tt.mode, tt.extender)
+		r.Anytime = tt.anytime
+		result := r.Plan()
+		if !result.Success {
+			t.Errorf("%s: no path found", tt.name)
+		}
+
+		seen := make(map[Point]int, len(r.PathV))
+		for i, p := range r.PathV {
+			if j, ok := seen[p]; ok {
+				t.Errorf("%s: nodes %d and %d are both at %v", tt.name, j, i, p)
+				break
+			}
+			seen[p] = i
+		}
+	}
+}
@@ -13,14 +13,11 @@ import (
 	"gonum.org/v1/plot/vg"
 )
 
-func addObstacle(p *plot.Plot, obstacles []*Obstacle, cl color.Color) {
+func addObstacle(p *plot.Plot, obstacles []Obstacle, cl color.Color) {
 	for _, obs := range obstacles {
-		pts := plotter.XYs{
-			{X: obs.X, Y: obs.Y},
-			{X: obs.X + obs.Width, Y: obs.Y},
-			{X: obs.X + obs.Width, Y: obs.Y + obs.Height},
-			{X: obs.X, Y: obs.Y + obs.Height},
-			{X: obs.X, Y: obs.Y},
+		var pts plotter.XYs
+		for _, v := range obs.Outline() {
+			pts = append(pts, plotter.XY{X: v.X, Y: v.Y})
 		}
 		pl, _ := plotter.NewPolygon(pts)
 		pl.Color = cl
@@ -31,12 +28,14 @@ func addObstacle(p *plot.Plot, obstacles []*Obstacle, cl color.Color) {
 // PlotRRT plots the RRT tree and the final path.
 func PlotRRT(r *RRT, filename string) error {
 	p := plot.New()
+	p.X.Min, p.X.Max = r.XMin, r.XMax
+	p.Y.Min, p.Y.Max = r.YMin, r.YMax
 
 	// Plot obstacles
 	addObstacle(p, r.Obstacles, color.Black)
 
 	// Plot the RRT tree
-	for _, edge := range r.PathE {
+	for _, edge := range r.treeEdges() {
 		line := plotter.XYs{
 			{X: edge[0].X, Y: edge[0].Y},
 			{X: edge[1].X, Y: edge[1].Y},
@@ -59,6 +58,12 @@ func PlotRRT(r *RRT, filename string) error {
 	pl.LineStyle.Width = vg.Points(3)
 	p.Add(pl)
 
+	// Mark the start and the goal
+	sc, _ := plotter.NewScatter(plotter.XYs{{X: r.Start.X, Y: r.Start.Y}, {X: r.Goal.X, Y: r.Goal.Y}})
+	sc.Color = color.RGBA{0, 0, 255, 255} // BLUE
+	sc.GlyphStyle.Radius = vg.Points(4)
+	p.Add(sc)
+
 	// Save the plot to a file
 	if err := p.Save(10*vg.Inch, 10*vg.Inch, filename); err != nil {
 		return err
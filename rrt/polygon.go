@@ -0,0 +1,111 @@
+package rrt
+
+import (
+	"math"
+)
+
+// Polygon represents an obstacle bounded by an arbitrary simple polygon,
+// convex or concave. Vertices are listed in order without repeating the
+// first one. A Polygon is inflated by the distance to its boundary, so the
+// inflated corners are rounded.
+type Polygon struct {
+	Vertices []Point
+}
+
+// NewPolygon creates a new Polygon instance.
+func NewPolygon(vertices ...Point) *Polygon {
+	return &Polygon{
+		Vertices: vertices,
+	}
+}
+
+// edge returns the i-th edge of the polygon.
+func (p *Polygon) edge(i int) (Point, Point) {
+	return p.Vertices[i], p.Vertices[(i+1)%len(p.Vertices)]
+}
+
+// closest returns the point on the polygon boundary closest to q.
+func (p *Polygon) closest(q Point) Point {
+	var best Point
+	minDist := math.MaxFloat64
+	for i := range p.Vertices {
+		a, b := p.edge(i)
+		c := closestOnSegment(q, a, b)
+		if d := math.Hypot(q.X-c.X, q.Y-c.Y); d < minDist {
+			minDist = d
+			best = c
+		}
+	}
+	return best
+}
+
+// Contains checks if a point (x, y) is inside the obstacle.
+func (p *Polygon) Contains(x, y float64) bool {
+	q := Point{X: x, Y: y}
+	return pointInPolygon(q, p.Vertices) || p.closest(q) == q
+}
+
+// IntersectsSegment implements Obstacle.
+func (p *Polygon) IntersectsSegment(p1, p2 Point, influenceRange float64) bool {
+	if len(p.Vertices) == 0 {
+		return false
+	}
+	if pointInPolygon(p1, p.Vertices) || pointInPolygon(p2, p.Vertices) {
+		return true
+	}
+	for i := range p.Vertices {
+		a, b := p.edge(i)
+		if segmentDistance(p1, p2, a, b) <= influenceRange {
+			return true
+		}
+	}
+	return false
+}
+
+// ClosestPoint implements Obstacle.
+func (p *Polygon) ClosestPoint(q Point, influenceRange float64) Point {
+	c := p.closest(q)
+	if influenceRange == 0 {
+		return c
+	}
+
+	// 沿边界法向偏移 influenceRange，内部的点需要反向
+	dir := Point{X: q.X - c.X, Y: q.Y - c.Y}
+	d := math.Hypot(dir.X, dir.Y)
+	if d == 0 {
+		return c
+	}
+	if pointInPolygon(q, p.Vertices) {
+		d = -d
+	}
+	return Point{X: c.X + dir.X/d*influenceRange, Y: c.Y + dir.Y/d*influenceRange}
+}
+
+// Distance implements Obstacle.
+func (p *Polygon) Distance(q Point, influenceRange float64) float64 {
+	c := p.closest(q)
+	return signedDistance(q, c, pointInPolygon(q, p.Vertices)) - influenceRange
+}
+
+// GetBounds implements Obstacle.
+func (p *Polygon) GetBounds(influenceRange float64) (float64, float64, float64, float64) {
+	if len(p.Vertices) == 0 {
+		return 0, 0, 0, 0
+	}
+
+	minX, minY := math.MaxFloat64, math.MaxFloat64
+	maxX, maxY := -math.MaxFloat64, -math.MaxFloat64
+	for _, v := range p.Vertices {
+		minX, maxX = math.Min(minX, v.X), math.Max(maxX, v.X)
+		minY, maxY = math.Min(minY, v.Y), math.Max(maxY, v.Y)
+	}
+	return minX - influenceRange, minY - influenceRange, maxX - minX + 2*influenceRange, maxY - minY + 2*influenceRange
+}
+
+// Outline implements Obstacle.
+func (p *Polygon) Outline() []Point {
+	if len(p.Vertices) == 0 {
+		return nil
+	}
+	return append(append([]Point{}, p.Vertices...), p.Vertices[0])
+}
@@ -6,7 +6,10 @@
 package rrt
 
 import (
+	"fmt"
 	"math"
+	"math/rand"
+	"time"
 )
 
 // Point represents a 2D point.
@@ -14,57 +17,129 @@ type Point struct {
 	X, Y float64
 }
 
+// Mode selects the planning algorithm used by Plan.
+type Mode int
+
+const (
+	ModeRRT       Mode = iota // 基本 RRT，找到第一条路径后停止
+	ModeRRTStar               // RRT*，选择最优父节点并重连邻居节点
+	ModeSpaceTime             // 空间-时间 RRT，节点带有到达时间，边按时间检查移动障碍物
+	ModeConnect               // RRT-Connect，从起点和目标同时生长两棵树并贪心地连接
+	ModeInformed              // Informed RRT*，找到第一条路径后只在椭圆内采样
+)
+
+var modeNames = map[Mode]string{
+	ModeRRT:       "rrt",
+	ModeRRTStar:   "rrtstar",
+	ModeSpaceTime: "spacetime",
+	ModeConnect:   "connect",
+	ModeInformed:  "informed",
+}
+
+// String returns the name of the mode as accepted by ParseMode.
+func (m Mode) String() string {
+	if name, ok := modeNames[m]; ok {
+		return name
+	}
+	return fmt.Sprintf("Mode(%d)", int(m))
+}
+
+// ParseMode returns the mode with the given name.
+func ParseMode(name string) (Mode, error) {
+	for m, n := range modeNames {
+		if n == name {
+			return m, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown mode %q", name)
+}
+
 // RRT represents the RRT algorithm.
 type RRT struct {
+	Mode           Mode
 	Start          Point
 	Goal           Point
 	Step           float64
 	Bias           float64
+	GoalProb       float64 // 以该概率直接采样目标点
 	NumNodes       int
+	XMin, YMin     float64 // 地图左下角，默认为原点
 	XMax, YMax     float64
-	Obstacles      []*Obstacle
+	OutOfBounds    BoundsPolicy // 新节点超出地图时的处理方式
+	WallRepulsion  bool         // APF 扩展时是否把地图边界当作产生斥力的墙
+	Obstacles      []Obstacle
 	InfluenceRange float64
-	PathV          []Point    // 树中的节点
-	Parent         []int      // 节点的索引，存储当前节点的父节点
-	PathE          [][2]Point // 树中的边
-	Path           []Point    // 最终的路径
+	Extender       Extender      // 扩展策略，为 nil 时沿直线扩展
+	Gamma          float64       // RRT* 邻域半径常数，为 0 时根据地图面积计算
+	Seed           int64         // 随机数种子，相同的种子和场景得到相同的树和路径
+	MaxSpeed       float64       // 空间-时间模式下的最大速度
+	MaxWait        float64       // 空间-时间模式下边发生碰撞时，在父节点最多等待的时间，为 0 时不等待
+	Anytime        bool          // 找到第一条路径后继续搜索，直到迭代次数、时间预算或 ctx 用尽
+	TimeBudget     time.Duration // 规划的时间预算，为 0 时不限制
+	TraceEvery     int           // 每隔多少次迭代记录一次搜索进度到 Result.Trace，为 0 时不记录
+
+	PathV  []Point    // 树中的节点
+	Parent []int      // 节点的索引，存储当前节点的父节点
+	Cost   []float64  // 从起点到当前节点的路径长度
+	Times  []float64  // 空间-时间模式下到达每个节点的时间
+	PathE  [][2]Point // 树中的边
+	Path   []Point    // 最终的路径
+
+	// OnImprove 在最优路径代价下降时被调用，path 是新的最优路径
+	OnImprove func(iteration int, cost float64, path []Point)
+
+	children  [][]int       // 每个节点的子节点，用于重连后更新代价
+	index     *KDTree       // PathV 的空间索引
+	goalTree  *RRT          // RRT-Connect 中从目标生长的树
+	iteration int           // 本次规划的当前迭代次数
+	extending int           // 当前被扩展的节点
+	checks    int           // 本次规划中边碰撞检测的次数
+	ellipse   float64       // Informed RRT* 采样椭圆的长轴长度，为 0 时在整个地图内采样
+	rng       *rand.Rand    // 由 Seed 初始化的随机数生成器
+	started   time.Time     // 本次规划的开始时间
+	trace     []TraceSample // 本次规划记录的搜索进度
 }
 
 // NewRRT creates a new RRT instance.
-func NewRRT(start, goal Point, step, bias float64, numNodes int, xMax, yMax float64, obstacles []*Obstacle, influenceRange float64) *RRT {
+func NewRRT(start, goal Point, step, bias float64, numNodes int, xMax, yMax float64, obstacles []Obstacle, influenceRange float64) *RRT {
 	return &RRT{
 		Start:          start,
 		Goal:           goal,
 		Step:           step,
 		Bias:           bias,
+		GoalProb:       0.3,
 		NumNodes:       numNodes,
 		XMax:           xMax,
 		YMax:           yMax,
 		Obstacles:      obstacles,
 		InfluenceRange: influenceRange,
+		Seed:           time.Now().UnixNano(),
+		MaxSpeed:       1,
 		PathV:          []Point{start},
 		Parent:         []int{-1},
+		Cost:           []float64{0},
 		PathE:          [][2]Point{},
 		Path:           []Point{},
+		children:       [][]int{nil},
 	}
 }
 
 // NearestPoint finds the nearest point in the tree to a given point.
 func (r *RRT) NearestPoint(randomPoint Point) (Point, int) {
-	minDist := math.MaxFloat64
-	var nearestPoint Point
-	var index int
-
-	for i, p := range r.PathV {
-		dist := r.EuclideanDistance(p, randomPoint)
-		if dist < minDist {
-			minDist = dist
-			nearestPoint = p
-			index = i
-		}
-	}
+	index, _ := r.spatialIndex().Nearest(randomPoint)
+	return r.PathV[index], index
+}
 
-	return nearestPoint, index
+// spatialIndex returns the KD-tree over PathV, bringing it up to date with
+// any nodes that were appended to PathV directly.
+func (r *RRT) spatialIndex() *KDTree {
+	if r.index == nil || r.index.Len() > len(r.PathV) {
+		r.index = NewKDTree()
+	}
+	for i := r.index.Len(); i < len(r.PathV); i++ {
+		r.index.Insert(r.PathV[i], i)
+	}
+	return r.index
 }
 
 // NewPoint generates a new point towards the random point.
@@ -84,6 +159,7 @@ func (r *RRT) NewPoint(nearestPoint, randomPoint Point) Point {
 
 // NoCollision checks if the path between two points is collision-free.
 func (r *RRT) NoCollision(p1, p2 Point) bool {
+	r.checks++
 	for _, obs := range r.Obstacles {
 		if r.CheckLineIntersection(p1, p2, obs) {
 			return false
@@ -92,17 +168,11 @@ func (r *RRT) NoCollision(p1, p2 Point) bool {
 	return true
 }
 
-// CheckLineIntersection checks if a line intersects with an obstacle.
-func (r *RRT) CheckLineIntersection(p1, p2 Point, obs *Obstacle) bool {
-	// Implement line-rectangle intersection logic here
-	// This is a simplified version, you may need to implement a more accurate collision detection
-	x1, y1, x2, y2 := p1.X, p1.Y, p2.X, p2.Y
-	ox, oy, ow, oh := obs.GetBounds(r.InfluenceRange)
-
-	// Check if the line intersects with the obstacle's bounds
-	// This is a placeholder, you should implement a proper line-rectangle intersection test
-	return x1 >= ox && x1 <= ox+ow && y1 >= oy && y1 <= oy+oh ||
-		x2 >= ox && x2 <= ox+ow && y2 >= oy && y2 <= oy+oh
+// CheckLineIntersection checks if the segment p1-p2 intersects the obstacle
+// inflated by InfluenceRange. Segments that touch the boundary or lie
+// entirely inside the obstacle count as intersecting.
+func (r *RRT) CheckLineIntersection(p1, p2 Point, obs Obstacle) bool {
+	return obs.IntersectsSegment(p1, p2, r.InfluenceRange)
 }
 
 // EuclideanDistance calculates the Euclidean distance between two points.
@@ -120,16 +190,22 @@ func (r *RRT) Direction(p1, p2 Point) Point {
 
 // ExtractPath extracts the final path from the goal to the start.
 func (r *RRT) ExtractPath(goalIndex int) {
-	r.Path = []Point{}
-	currentIndex := goalIndex
+	r.Path = r.pathTo(goalIndex)
+}
+
+// pathTo returns the tree path from the root to the given node.
+func (r *RRT) pathTo(index int) []Point {
+	path := []Point{}
+	currentIndex := index
 
 	for currentIndex != -1 {
-		r.Path = append(r.Path, r.PathV[currentIndex])
+		path = append(path, r.PathV[currentIndex])
 		currentIndex = r.Parent[currentIndex]
 	}
 
 	// Reverse the path to get it from start to goal
-	for i, j := 0, len(r.Path)-1; i < j; i, j = i+1, j-1 {
-		r.Path[i], r.Path[j] = r.Path[j], r.Path[i]
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
 	}
-}
\ No newline at end of file
+	return path
+}
@@ -0,0 +1,61 @@
+package rrt
+
+import (
+	"math"
+)
+
+// SearchState is the progress of a planning run at the moment a node is
+// extended.
+type SearchState struct {
+	Iteration    int     // 当前迭代次数，从 1 开始
+	TreeSize     int     // 树中的节点数
+	GoalDistance float64 // 被扩展的节点到目标的距离
+	Cost         float64 // 被扩展的节点的路径代价
+	Time         float64 // 空间-时间模式下到达被扩展的节点的时间
+}
+
+// RangeSchedule returns the factor applied to the repulsion range of
+// ImprovedAPF. It only depends on the search state, so a run is fully
+// determined by RRT.Seed.
+type RangeSchedule func(s SearchState) float64
+
+// IterationSchedule oscillates the range between half and full strength,
+// completing a half period every period·π iterations.
+func IterationSchedule(period float64) RangeSchedule {
+	return func(s SearchState) float64 {
+		return 1 - 0.5*math.Abs(math.Sin(float64(s.Iteration)/period))
+	}
+}
+
+// TreeSizeSchedule is like IterationSchedule but driven by the number of
+// nodes in the tree, so iterations that add nothing do not move it.
+func TreeSizeSchedule(period float64) RangeSchedule {
+	return func(s SearchState) float64 {
+		return 1 - 0.5*math.Abs(math.Sin(float64(s.TreeSize)/period))
+	}
+}
+
+// GoalDistanceSchedule keeps the full range far from the goal and shrinks
+// it linearly to half within distance of the goal, so obstacles close to
+// the goal push the tree away less.
+func GoalDistanceSchedule(distance float64) RangeSchedule {
+	return func(s SearchState) float64 {
+		return 0.5 + 0.5*math.Min(1, s.GoalDistance/distance)
+	}
+}
+
+// searchState returns the current progress with p as the extended node.
+func (r *RRT) searchState(p Point) SearchState {
+	s := SearchState{
+		Iteration:    r.iteration,
+		TreeSize:     len(r.PathV),
+		GoalDistance: r.EuclideanDistance(p, r.Goal),
+	}
+	if r.extending < len(r.Cost) {
+		s.Cost = r.Cost[r.extending]
+	}
+	if r.extending < len(r.Times) {
+		s.Time = r.Times[r.extending]
+	}
+	return s
+}
@@ -0,0 +1,72 @@
+package rrt
+
+// NoCollisionDuring checks if a point driven in a straight line from p1 at
+// time t1 to p2 at time t2 stays clear of all obstacles. Static obstacles
+// are checked like in NoCollision, moving ones at their positions over the
+// time interval.
+func (r *RRT) NoCollisionDuring(p1, p2 Point, t1, t2 float64) bool {
+	r.checks++
+	for _, obs := range r.Obstacles {
+		if m, ok := obs.(MovingObstacle); ok {
+			if m.IntersectsMotion(p1, p2, t1, t2, r.InfluenceRange) {
+				return false
+			}
+			continue
+		}
+		if r.CheckLineIntersection(p1, p2, obs) {
+			return false
+		}
+	}
+	return true
+}
+
+// connect checks the edge from node from to p and returns the arrival
+// time at p. Points outside the workspace are refused. Outside
+// ModeSpaceTime the time is always 0.
+//
+// In ModeSpaceTime the edge is driven at MaxSpeed. If that collides and
+// MaxWait is set, the robot waits a random time at the node first and the
+// edge is checked once more.
+func (r *RRT) connect(from int, p Point) (float64, bool) {
+	q := r.PathV[from]
+	if !r.InBounds(p) {
+		return 0, false
+	}
+	if r.Mode != ModeSpaceTime {
+		return 0, r.NoCollision(q, p)
+	}
+
+	t0 := r.Times[from]
+	travel := r.EuclideanDistance(q, p) / r.MaxSpeed
+	if r.NoCollisionDuring(q, p, t0, t0+travel) {
+		return t0 + travel, true
+	}
+	if r.MaxWait <= 0 {
+		return 0, false
+	}
+
+	// 原地等待的过程中同样可能被移动障碍物撞上
+	wait := r.Rand().Float64() * r.MaxWait
+	if !r.NoCollisionDuring(q, q, t0, t0+wait) || !r.NoCollisionDuring(q, p, t0+wait, t0+wait+travel) {
+		return 0, false
+	}
+	return t0 + wait + travel, true
+}
+
+// pathTimes returns the arrival times along the path to goalIndex, or nil
+// outside ModeSpaceTime. A gap longer than the travel time at MaxSpeed
+// means the robot waits at the earlier point.
+func (r *RRT) pathTimes(goalIndex int) []float64 {
+	if r.Times == nil {
+		return nil
+	}
+
+	var times []float64
+	for i := goalIndex; i != -1; i = r.Parent[i] {
+		times = append(times, r.Times[i])
+	}
+	for i, j := 0, len(times)-1; i < j; i, j = i+1, j-1 {
+		times[i], times[j] = times[j], times[i]
+	}
+	return times
+}
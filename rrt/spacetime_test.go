@@ -0,0 +1,73 @@
+package rrt
+
+import (
+	"testing"
+)
+
+// movingProblem builds a spacetime planner on the map of
+// scenarios/moving.yaml: a circle moving at constant velocity and a
+// rectangle going up and back down through waypoints.
+func movingProblem(extender Extender, seed int64) *RRT {
+	obstacles := []Obstacle{
+		NewMoving(NewCircle(Point{X: 300, Y: 300}, 100), LinearMotion{V: Point{X: 2, Y: 0}}),
+		NewMoving(NewRect(550, 450, 200, 80), NewTrajectory(
+			Waypoint{T: 0, Offset: Point{X: 0, Y: 0}},
+			Waypoint{T: 100, Offset: Point{X: 0, Y: 300}},
+			Waypoint{T: 200, Offset: Point{X: 0, Y: 0}},
+		)),
+	}
+	r := NewRRT(Point{X: 0, Y: 0}, Point{X: 999, Y: 999}, 20, 20, 5000, 1000, 1000, obstacles, 10)
+	r.Mode = ModeSpaceTime
+	r.Extender = extender
+	r.Seed = seed
+	r.MaxSpeed = 10
+	r.MaxWait = 20
+	return r
+}
+
+func TestSpaceTimePathAvoidsMovingObstacles(t *testing.T) {
+	improved := NewImprovedAPF(1, 0.5, 50)
+	improved.Speed = 10
+	for _, tt := range []struct {
+		name     string
+		extender Extender
+	}{{"straight", nil}, {"improved-apf", improved}} {
+		name, extender := tt.name, tt.extender
+		for seed := int64(1); seed <= 5; seed++ {
+			r := movingProblem(extender, seed)
+			result := r.Plan()
+			if !result.Success {
+				t.Errorf("%s, seed %d: no path found", name, seed)
+				continue
+			}
+
+			path, times := result.Path, result.Times
+			if len(times) != len(path) {
+				t.Fatalf("%s, seed %d: %d arrival times for %d waypoints", name, seed, len(times), len(path))
+			}
+			for i := 1; i < len(path); i++ {
+				if times[i] < times[i-1] {
+					t.Errorf("%s, seed %d: arrival time goes back from %v to %v at waypoint %d",
+						name, seed, times[i-1], times[i], i)
+				}
+				if !r.NoCollisionDuring(path[i-1], path[i], times[i-1], times[i]) {
+					t.Errorf("%s, seed %d: edge %d from %v at t=%v to %v at t=%v hits a moving obstacle",
+						name, seed, i, path[i-1], times[i-1], path[i], times[i])
+				}
+
+				// 与 IntersectsMotion 无关的检查：沿边密集采样时间，逐点检查障碍物当时的位置
+				const samples = 50
+				for k := 0; k <= samples; k++ {
+					s := float64(k) / samples
+					p := Point{X: path[i-1].X + s*(path[i].X-path[i-1].X), Y: path[i-1].Y + s*(path[i].Y-path[i-1].Y)}
+					at := times[i-1] + s*(times[i]-times[i-1])
+					for _, obs := range r.Obstacles {
+						if obs.(MovingObstacle).At(at).IntersectsSegment(p, p, r.InfluenceRange) {
+							t.Fatalf("%s, seed %d: %v at t=%v is inside an obstacle", name, seed, p, at)
+						}
+					}
+				}
+			}
+		}
+	}
+}
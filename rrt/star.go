@@ -0,0 +1,98 @@
+package rrt
+
+import (
+	"math"
+)
+
+// NeighborRadius returns the RRT* neighbourhood radius for the current tree
+// size. It shrinks as gamma*sqrt(log(n)/n) but never drops below Step.
+func (r *RRT) NeighborRadius() float64 {
+	gamma := r.Gamma
+	if gamma == 0 {
+		// γ > 2(1+1/d)^(1/d) (μ(X)/ζ_d)^(1/d), d = 2
+		gamma = 2 * math.Sqrt(1.5) * math.Sqrt((r.XMax-r.XMin)*(r.YMax-r.YMin)/math.Pi)
+	}
+
+	n := float64(len(r.PathV) + 1)
+	radius := gamma * math.Sqrt(math.Log(n)/n)
+	return math.Max(radius, r.Step)
+}
+
+// Near returns the indices of all tree nodes within radius of p.
+func (r *RRT) Near(p Point, radius float64) []int {
+	return r.spatialIndex().Within(p, radius)
+}
+
+// chooseParent picks the neighbour that reaches p with the lowest
+// cost-to-come through a collision-free edge.
+func (r *RRT) chooseParent(p Point, nearestIndex int, neighbors []int) int {
+	parent := nearestIndex
+	minCost := r.Cost[nearestIndex] + r.EuclideanDistance(r.PathV[nearestIndex], p)
+
+	for _, i := range neighbors {
+		if i == nearestIndex {
+			continue
+		}
+		cost := r.Cost[i] + r.EuclideanDistance(r.PathV[i], p)
+		if cost < minCost && r.NoCollision(r.PathV[i], p) {
+			parent = i
+			minCost = cost
+		}
+	}
+	return parent
+}
+
+// rewire reconnects neighbours through the new node when that lowers their
+// cost-to-come.
+func (r *RRT) rewire(index int, neighbors []int) {
+	p := r.PathV[index]
+	for _, i := range neighbors {
+		if i == r.Parent[index] {
+			continue
+		}
+		cost := r.Cost[index] + r.EuclideanDistance(p, r.PathV[i])
+		if cost >= r.Cost[i] || !r.NoCollision(p, r.PathV[i]) {
+			continue
+		}
+		r.setParent(i, index)
+		r.updateCost(i, cost-r.Cost[i])
+	}
+}
+
+// setParent moves node i under a new parent.
+func (r *RRT) setParent(i, parent int) {
+	siblings := r.children[r.Parent[i]]
+	for k, c := range siblings {
+		if c == i {
+			r.children[r.Parent[i]] = append(siblings[:k], siblings[k+1:]...)
+			break
+		}
+	}
+
+	r.Parent[i] = parent
+	r.children[parent] = append(r.children[parent], i)
+	r.PathE[i-1] = [2]Point{r.PathV[parent], r.PathV[i]}
+}
+
+// updateCost shifts the cost of node i and all of its descendants by delta.
+func (r *RRT) updateCost(i int, delta float64) {
+	stack := []int{i}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		r.Cost[n] += delta
+		stack = append(stack, r.children[n]...)
+	}
+}
+
+// bestGoal returns the goal node with the lowest cost, or -1 if there is none.
+func (r *RRT) bestGoal(goalNodes []int) int {
+	best := -1
+	for _, i := range goalNodes {
+		if best == -1 || r.Cost[i] < r.Cost[best] {
+			best = i
+		}
+	}
+	return best
+}
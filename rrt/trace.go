@@ -0,0 +1,50 @@
+package rrt
+
+import (
+	"math"
+	"time"
+)
+
+// TraceSample is a snapshot of the search progress, recorded every
+// TraceEvery iterations.
+type TraceSample struct {
+	Iteration int
+	Elapsed   time.Duration // 从开始规划到采样时的耗时
+	Nodes     int           // 树中的节点数，ModeConnect 下为两棵树之和
+	Cost      float64       // 当前最优路径代价，尚未找到路径时为 +Inf
+}
+
+// record appends a TraceSample if TraceEvery is set and iterations is a
+// multiple of it. With final set the sample is taken regardless, unless
+// the same iteration has just been recorded.
+func (r *RRT) record(iterations int, cost float64, final bool) {
+	if r.TraceEvery <= 0 {
+		return
+	}
+	if n := len(r.trace); n > 0 && r.trace[n-1].Iteration == iterations {
+		return
+	}
+	if !final && iterations%r.TraceEvery != 0 {
+		return
+	}
+
+	nodes := len(r.PathV)
+	if r.goalTree != nil {
+		nodes += len(r.goalTree.PathV)
+	}
+	r.trace = append(r.trace, TraceSample{
+		Iteration: iterations,
+		Elapsed:   time.Since(r.started),
+		Nodes:     nodes,
+		Cost:      cost,
+	})
+}
+
+// bestCost returns the last cost of a convergence curve, or +Inf if no
+// path has been found yet.
+func bestCost(curve []CostSample) float64 {
+	if n := len(curve); n > 0 {
+		return curve[n-1].Cost
+	}
+	return math.Inf(1)
+}
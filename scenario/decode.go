@@ -0,0 +1,560 @@
+package scenario
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/bz-2021/rrt_star/rrt"
+)
+
+// Error is a problem found in a scenario file, with the position of the
+// offending value.
+type Error struct {
+	File         string
+	Line, Column int
+	Msg          string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Msg)
+}
+
+// Parse decodes a scenario from data. name is used to pick the format and
+// in error messages.
+func Parse(name string, data []byte) (*Scenario, error) {
+	if strings.EqualFold(filepath.Ext(name), ".json") {
+		if err := checkJSON(name, data); err != nil {
+			return nil, err
+		}
+	}
+
+	// JSON 是 YAML 的子集，统一解析为 yaml.Node 以保留行号
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, yamlError(name, err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, &Error{File: name, Line: 1, Column: 1, Msg: "empty scenario"}
+	}
+
+	d := &decoder{file: name}
+	return d.scenario(doc.Content[0])
+}
+
+// checkJSON reports JSON syntax errors with their line and column.
+func checkJSON(name string, data []byte) error {
+	var v any
+	err := json.Unmarshal(data, &v)
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		// Offset 是读到出错字节之后的位置
+		line, col := position(data, max(syntaxErr.Offset-1, 0))
+		return &Error{File: name, Line: line, Column: col, Msg: syntaxErr.Error()}
+	}
+	return err
+}
+
+// position converts a byte offset into a 1-based line and column.
+func position(data []byte, offset int64) (int, int) {
+	offset = min(offset, int64(len(data)))
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	col := int(offset) - bytes.LastIndexByte(before, '\n')
+	return line, col
+}
+
+// yamlError turns a yaml.v3 syntax error into an Error.
+func yamlError(name string, err error) error {
+	msg := strings.TrimPrefix(err.Error(), "yaml: ")
+	var line int
+	if _, scanErr := fmt.Sscanf(msg, "line %d:", &line); scanErr == nil {
+		msg = strings.TrimSpace(msg[strings.Index(msg, ":")+1:])
+		return &Error{File: name, Line: line, Column: 1, Msg: msg}
+	}
+	return &Error{File: name, Line: 1, Column: 1, Msg: msg}
+}
+
+// decoder walks the YAML node tree, checking types and values as it goes.
+type decoder struct {
+	file string
+}
+
+func (d *decoder) errorf(n *yaml.Node, format string, args ...any) error {
+	return &Error{File: d.file, Line: n.Line, Column: n.Column, Msg: fmt.Sprintf(format, args...)}
+}
+
+// mapping calls the handler of each key in n and returns the keys seen.
+// Unknown and duplicate keys are errors.
+func (d *decoder) mapping(n *yaml.Node, path string, fields map[string]func(*yaml.Node) error) (map[string]bool, error) {
+	if n.Kind != yaml.MappingNode {
+		return nil, d.errorf(n, "%s: expected a mapping", path)
+	}
+
+	seen := map[string]bool{}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		handle, ok := fields[key.Value]
+		if !ok {
+			return nil, d.errorf(key, "%s: unknown field %q", path, key.Value)
+		}
+		if seen[key.Value] {
+			return nil, d.errorf(key, "%s: duplicate field %q", path, key.Value)
+		}
+		seen[key.Value] = true
+		if err := handle(value); err != nil {
+			return nil, err
+		}
+	}
+	return seen, nil
+}
+
+// require checks that all keys are present in the mapping n.
+func (d *decoder) require(n *yaml.Node, path string, seen map[string]bool, keys ...string) error {
+	for _, key := range keys {
+		if !seen[key] {
+			return d.errorf(n, "%s: missing field %q", path, key)
+		}
+	}
+	return nil
+}
+
+func (d *decoder) float(n *yaml.Node, path string) (float64, error) {
+	if n.Kind == yaml.ScalarNode && (n.Tag == "!!int" || n.Tag == "!!float") {
+		if v, err := strconv.ParseFloat(n.Value, 64); err == nil && !math.IsInf(v, 0) && !math.IsNaN(v) {
+			return v, nil
+		}
+	}
+	return 0, d.errorf(n, "%s: expected a number, got %q", path, n.Value)
+}
+
+func (d *decoder) int(n *yaml.Node, path string) (int, error) {
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!int" {
+		if v, err := strconv.Atoi(n.Value); err == nil {
+			return v, nil
+		}
+	}
+	return 0, d.errorf(n, "%s: expected an integer, got %q", path, n.Value)
+}
+
+func (d *decoder) string(n *yaml.Node, path string) (string, error) {
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!str" {
+		return n.Value, nil
+	}
+	return "", d.errorf(n, "%s: expected a string", path)
+}
+
+// point accepts either [x, y] or {x: .., y: ..}.
+func (d *decoder) point(n *yaml.Node, path string) (rrt.Point, error) {
+	var p rrt.Point
+	if n.Kind == yaml.SequenceNode {
+		if len(n.Content) != 2 {
+			return p, d.errorf(n, "%s: expected [x, y]", path)
+		}
+		var err error
+		if p.X, err = d.float(n.Content[0], path+"[0]"); err != nil {
+			return p, err
+		}
+		p.Y, err = d.float(n.Content[1], path+"[1]")
+		return p, err
+	}
+
+	seen, err := d.mapping(n, path, map[string]func(*yaml.Node) error{
+		"x": d.setFloat(&p.X, path+".x"),
+		"y": d.setFloat(&p.Y, path+".y"),
+	})
+	if err != nil {
+		return p, err
+	}
+	return p, d.require(n, path, seen, "x", "y")
+}
+
+func (d *decoder) setFloat(dst *float64, path string) func(*yaml.Node) error {
+	return func(n *yaml.Node) (err error) {
+		*dst, err = d.float(n, path)
+		return err
+	}
+}
+
+func (d *decoder) setPositive(dst *float64, path string) func(*yaml.Node) error {
+	return func(n *yaml.Node) (err error) {
+		if *dst, err = d.float(n, path); err == nil && *dst <= 0 {
+			err = d.errorf(n, "%s: must be positive", path)
+		}
+		return err
+	}
+}
+
+func (d *decoder) setPoint(dst *rrt.Point, path string) func(*yaml.Node) error {
+	return func(n *yaml.Node) (err error) {
+		*dst, err = d.point(n, path)
+		return err
+	}
+}
+
+func (d *decoder) setBool(dst *bool, path string) func(*yaml.Node) error {
+	return func(n *yaml.Node) error {
+		v, err := strconv.ParseBool(n.Value)
+		if n.Kind != yaml.ScalarNode || n.Tag != "!!bool" || err != nil {
+			return d.errorf(n, "%s: expected true or false", path)
+		}
+		*dst = v
+		return nil
+	}
+}
+
+func (d *decoder) setString(dst *string, path string) func(*yaml.Node) error {
+	return func(n *yaml.Node) (err error) {
+		*dst, err = d.string(n, path)
+		return err
+	}
+}
+
+func (d *decoder) scenario(n *yaml.Node) (*Scenario, error) {
+	s := &Scenario{Planner: DefaultPlanner()}
+	nodes := map[string]*yaml.Node{}
+	keep := func(key string, handle func(*yaml.Node) error) func(*yaml.Node) error {
+		return func(n *yaml.Node) error {
+			nodes[key] = n
+			return handle(n)
+		}
+	}
+
+	seen, err := d.mapping(n, "scenario", map[string]func(*yaml.Node) error{
+		"name":      d.setString(&s.Name, "name"),
+		"bounds":    keep("bounds", func(n *yaml.Node) error { return d.bounds(n, &s.Bounds) }),
+		"start":     keep("start", d.setPoint(&s.Start, "start")),
+		"goal":      keep("goal", d.setPoint(&s.Goal, "goal")),
+		"inflation": keep("inflation", d.setFloat(&s.Inflation, "inflation")),
+		"obstacles": func(n *yaml.Node) (err error) {
+			s.Obstacles, err = d.obstacles(n)
+			return err
+		},
+		"planner": func(n *yaml.Node) error { return d.planner(n, &s.Planner) },
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := d.require(n, "scenario", seen, "bounds", "start", "goal"); err != nil {
+		return nil, err
+	}
+
+	if s.Inflation < 0 {
+		return nil, d.errorf(nodes["inflation"], "inflation: must not be negative")
+	}
+	for _, key := range []string{"start", "goal"} {
+		p := s.Start
+		if key == "goal" {
+			p = s.Goal
+		}
+		if p.X < s.Bounds.Min.X || p.X > s.Bounds.Max.X || p.Y < s.Bounds.Min.Y || p.Y > s.Bounds.Max.Y {
+			return nil, d.errorf(nodes[key], "%s: (%g, %g) is outside the bounds", key, p.X, p.Y)
+		}
+		for i, obs := range s.Obstacles {
+			if obs.Contains(p.X, p.Y) {
+				return nil, d.errorf(nodes[key], "%s: (%g, %g) is inside obstacles[%d]", key, p.X, p.Y, i)
+			}
+		}
+	}
+	return s, nil
+}
+
+func (d *decoder) bounds(n *yaml.Node, b *Bounds) error {
+	seen, err := d.mapping(n, "bounds", map[string]func(*yaml.Node) error{
+		"min": d.setPoint(&b.Min, "bounds.min"),
+		"max": d.setPoint(&b.Max, "bounds.max"),
+	})
+	if err != nil {
+		return err
+	}
+	if err := d.require(n, "bounds", seen, "max"); err != nil {
+		return err
+	}
+
+	if b.Max.X <= b.Min.X || b.Max.Y <= b.Min.Y {
+		return d.errorf(n, "bounds: max must be greater than min on both axes")
+	}
+	return nil
+}
+
+func (d *decoder) obstacles(n *yaml.Node) ([]rrt.Obstacle, error) {
+	if n.Kind != yaml.SequenceNode {
+		return nil, d.errorf(n, "obstacles: expected a list")
+	}
+
+	obstacles := make([]rrt.Obstacle, 0, len(n.Content))
+	for i, item := range n.Content {
+		obs, err := d.obstacle(item, fmt.Sprintf("obstacles[%d]", i))
+		if err != nil {
+			return nil, err
+		}
+		obstacles = append(obstacles, obs)
+	}
+	return obstacles, nil
+}
+
+func (d *decoder) obstacle(n *yaml.Node, path string) (rrt.Obstacle, error) {
+	if n.Kind != yaml.MappingNode {
+		return nil, d.errorf(n, "%s: expected a mapping", path)
+	}
+
+	// motion 可以出现在任意类型的障碍物上，先把它取出来再解析形状
+	var motion *yaml.Node
+	shape := *n
+	shape.Content = nil
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if key := n.Content[i]; key.Value == "motion" {
+			if motion != nil {
+				return nil, d.errorf(key, "%s: duplicate field %q", path, key.Value)
+			}
+			motion = n.Content[i+1]
+			continue
+		}
+		shape.Content = append(shape.Content, n.Content[i], n.Content[i+1])
+	}
+
+	o, err := d.shape(&shape, path)
+	if err != nil || motion == nil {
+		return o, err
+	}
+	m, err := d.motion(motion, path+".motion")
+	if err != nil {
+		return nil, err
+	}
+	return rrt.NewMoving(o, m), nil
+}
+
+// motion decodes either {velocity: [vx, vy]} or a list of waypoints
+// {waypoints: [{t: 0, offset: [0, 0]}, ...]}.
+func (d *decoder) motion(n *yaml.Node, path string) (rrt.Motion, error) {
+	var m rrt.Motion
+	seen, err := d.mapping(n, path, map[string]func(*yaml.Node) error{
+		"velocity": func(n *yaml.Node) error {
+			v, err := d.point(n, path+".velocity")
+			m = rrt.LinearMotion{V: v}
+			return err
+		},
+		"waypoints": func(n *yaml.Node) error {
+			if n.Kind != yaml.SequenceNode || len(n.Content) == 0 {
+				return d.errorf(n, "%s.waypoints: expected a non-empty list", path)
+			}
+			var waypoints []rrt.Waypoint
+			for i, v := range n.Content {
+				wpath := fmt.Sprintf("%s.waypoints[%d]", path, i)
+				var w rrt.Waypoint
+				seen, err := d.mapping(v, wpath, map[string]func(*yaml.Node) error{
+					"t":      d.setFloat(&w.T, wpath+".t"),
+					"offset": d.setPoint(&w.Offset, wpath+".offset"),
+				})
+				if err != nil {
+					return err
+				}
+				if err := d.require(v, wpath, seen, "t", "offset"); err != nil {
+					return err
+				}
+				if i > 0 && w.T <= waypoints[i-1].T {
+					return d.errorf(v, "%s.t: waypoint times must be increasing", wpath)
+				}
+				waypoints = append(waypoints, w)
+			}
+			m = rrt.NewTrajectory(waypoints...)
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if seen["velocity"] == seen["waypoints"] {
+		return nil, d.errorf(n, "%s: expected exactly one of %q or %q", path, "velocity", "waypoints")
+	}
+	return m, nil
+}
+
+func (d *decoder) shape(n *yaml.Node, path string) (rrt.Obstacle, error) {
+	var typ *yaml.Node
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == "type" {
+			typ = n.Content[i+1]
+		}
+	}
+	if typ == nil {
+		return nil, d.errorf(n, "%s: missing field %q", path, "type")
+	}
+	ignore := func(*yaml.Node) error { return nil }
+
+	switch typ.Value {
+	case "rect":
+		o := &rrt.Rect{}
+		seen, err := d.mapping(n, path, map[string]func(*yaml.Node) error{
+			"type":   ignore,
+			"x":      d.setFloat(&o.X, path+".x"),
+			"y":      d.setFloat(&o.Y, path+".y"),
+			"width":  d.setPositive(&o.Width, path+".width"),
+			"height": d.setPositive(&o.Height, path+".height"),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return o, d.require(n, path, seen, "x", "y", "width", "height")
+
+	case "circle":
+		o := &rrt.Circle{}
+		seen, err := d.mapping(n, path, map[string]func(*yaml.Node) error{
+			"type":   ignore,
+			"center": d.setPoint(&o.Center, path+".center"),
+			"radius": d.setPositive(&o.Radius, path+".radius"),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return o, d.require(n, path, seen, "center", "radius")
+
+	case "oriented_box":
+		var center rrt.Point
+		var width, height, angle float64
+		seen, err := d.mapping(n, path, map[string]func(*yaml.Node) error{
+			"type":   ignore,
+			"center": d.setPoint(&center, path+".center"),
+			"width":  d.setPositive(&width, path+".width"),
+			"height": d.setPositive(&height, path+".height"),
+			"angle":  d.setFloat(&angle, path+".angle"),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := d.require(n, path, seen, "center", "width", "height"); err != nil {
+			return nil, err
+		}
+		return rrt.NewOrientedBox(center, width, height, angle*math.Pi/180), nil
+
+	case "polygon":
+		o := &rrt.Polygon{}
+		seen, err := d.mapping(n, path, map[string]func(*yaml.Node) error{
+			"type": ignore,
+			"vertices": func(n *yaml.Node) error {
+				if n.Kind != yaml.SequenceNode || len(n.Content) < 3 {
+					return d.errorf(n, "%s.vertices: expected a list of at least 3 points", path)
+				}
+				for i, v := range n.Content {
+					p, err := d.point(v, fmt.Sprintf("%s.vertices[%d]", path, i))
+					if err != nil {
+						return err
+					}
+					o.Vertices = append(o.Vertices, p)
+				}
+				return nil
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return o, d.require(n, path, seen, "vertices")
+	}
+	return nil, d.errorf(typ, "%s.type: unknown obstacle type %q (want rect, circle, oriented_box or polygon)", path, typ.Value)
+}
+
+func (d *decoder) planner(n *yaml.Node, p *Planner) error {
+	var maxNodes *yaml.Node
+	_, err := d.mapping(n, "planner", map[string]func(*yaml.Node) error{
+		"algorithm": func(n *yaml.Node) (err error) {
+			if p.Algorithm, err = d.string(n, "planner.algorithm"); err == nil {
+				if _, perr := rrt.ParseMode(p.Algorithm); perr != nil {
+					err = d.errorf(n, "planner.algorithm: %v", perr)
+				}
+			}
+			return err
+		},
+		"steering": func(n *yaml.Node) (err error) {
+			if p.Steering, err = d.string(n, "planner.steering"); err == nil {
+				if _, serr := NewExtender(p.Steering, p.APF); serr != nil {
+					err = d.errorf(n, "planner.steering: %v", serr)
+				}
+			}
+			return err
+		},
+		"step": d.setPositive(&p.Step, "planner.step"),
+		"bias": d.setPositive(&p.Bias, "planner.bias"),
+		"goal_prob": func(n *yaml.Node) (err error) {
+			if p.GoalProb, err = d.float(n, "planner.goal_prob"); err == nil && (p.GoalProb < 0 || p.GoalProb > 1) {
+				err = d.errorf(n, "planner.goal_prob: must be between 0 and 1")
+			}
+			return err
+		},
+		"max_nodes": func(n *yaml.Node) (err error) {
+			maxNodes = n
+			if p.MaxNodes, err = d.int(n, "planner.max_nodes"); err == nil && p.MaxNodes < 0 {
+				err = d.errorf(n, "planner.max_nodes: must not be negative")
+			}
+			return err
+		},
+		"gamma": func(n *yaml.Node) (err error) {
+			if p.Gamma, err = d.float(n, "planner.gamma"); err == nil && p.Gamma < 0 {
+				err = d.errorf(n, "planner.gamma: must not be negative")
+			}
+			return err
+		},
+		"max_speed": d.setPositive(&p.MaxSpeed, "planner.max_speed"),
+		"out_of_bounds": func(n *yaml.Node) (err error) {
+			if p.OutOfBounds, err = d.string(n, "planner.out_of_bounds"); err == nil {
+				if _, perr := rrt.ParseBoundsPolicy(p.OutOfBounds); perr != nil {
+					err = d.errorf(n, "planner.out_of_bounds: %v", perr)
+				}
+			}
+			return err
+		},
+		"wall_repulsion": d.setBool(&p.WallRepulsion, "planner.wall_repulsion"),
+		"anytime":        d.setBool(&p.Anytime, "planner.anytime"),
+		"time_budget": func(n *yaml.Node) error {
+			s, err := d.string(n, "planner.time_budget")
+			if err != nil {
+				return err
+			}
+			if p.TimeBudget, err = time.ParseDuration(s); err != nil || p.TimeBudget < 0 {
+				return d.errorf(n, "planner.time_budget: expected a duration such as \"200ms\"")
+			}
+			return nil
+		},
+		"max_wait": func(n *yaml.Node) (err error) {
+			if p.MaxWait, err = d.float(n, "planner.max_wait"); err == nil && p.MaxWait < 0 {
+				err = d.errorf(n, "planner.max_wait: must not be negative")
+			}
+			return err
+		},
+		"apf": func(n *yaml.Node) error {
+			_, err := d.mapping(n, "planner.apf", map[string]func(*yaml.Node) error{
+				"kp":   d.setFloat(&p.APF.Kp, "planner.apf.kp"),
+				"krep": d.setFloat(&p.APF.Krep, "planner.apf.krep"),
+				"p0":   d.setPositive(&p.APF.P0, "planner.apf.p0"),
+				"speed": func(n *yaml.Node) (err error) {
+					if p.APF.Speed, err = d.float(n, "planner.apf.speed"); err == nil && p.APF.Speed < 0 {
+						err = d.errorf(n, "planner.apf.speed: must not be negative")
+					}
+					return err
+				},
+				"schedule": func(n *yaml.Node) (err error) {
+					if p.APF.Schedule, err = d.string(n, "planner.apf.schedule"); err == nil {
+						if _, serr := ParseSchedule(p.APF.Schedule); serr != nil {
+							err = d.errorf(n, "planner.apf.schedule: %v", serr)
+						}
+					}
+					return err
+				},
+			})
+			return err
+		},
+	})
+	if err == nil && p.MaxNodes == 0 && p.TimeBudget == 0 {
+		// 只有设置了时间预算时才允许不限迭代次数
+		err = d.errorf(maxNodes, "planner.max_nodes: must be positive without time_budget")
+	}
+	return err
+}
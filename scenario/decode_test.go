@@ -0,0 +1,91 @@
+package scenario
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/bz-2021/rrt_star/rrt"
+)
+
+// head is the smallest valid scenario; the cases append to it from line 5.
+const head = "bounds:\n  max: [1000, 1000]\nstart: [0, 0]\ngoal: [999, 999]\n"
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name, file, data string
+		want             string
+	}{
+		{
+			name: "bad number",
+			file: "t.yaml",
+			data: head + "planner:\n  step: abc\n",
+			want: `t.yaml:6:9: planner.step: expected a number, got "abc"`,
+		},
+		{
+			name: "negative width",
+			file: "t.yaml",
+			data: head + "obstacles:\n  - type: rect\n    x: 1\n    y: 1\n    width: -5\n    height: 5\n",
+			want: "t.yaml:9:12: obstacles[0].width: must be positive",
+		},
+		{
+			name: "duplicate key",
+			file: "t.yaml",
+			data: head + "goal: [1, 1]\n",
+			want: `t.yaml:5:1: scenario: duplicate field "goal"`,
+		},
+		{
+			name: "duplicate motion",
+			file: "t.yaml",
+			data: head + "obstacles:\n  - type: circle\n    center: [500, 500]\n    radius: 10\n" +
+				"    motion: {velocity: [1, 0]}\n    motion: {velocity: [0, 1]}\n",
+			want: `t.yaml:10:5: obstacles[0]: duplicate field "motion"`,
+		},
+		{
+			name: "unknown type",
+			file: "t.yaml",
+			data: head + "obstacles:\n  - type: square\n",
+			want: `t.yaml:6:11: obstacles[0].type: unknown obstacle type "square" (want rect, circle, oriented_box or polygon)`,
+		},
+		{
+			name: "json syntax",
+			file: "t.json",
+			data: "{\n  \"start\": [0, 0],\n  \"goal\": [999 999]\n}\n",
+			want: "t.json:3:16: invalid character '9' after array element",
+		},
+		{
+			name: "start inside obstacle",
+			file: "t.yaml",
+			data: head + "obstacles:\n  - type: circle\n    center: [0, 0]\n    radius: 10\n",
+			want: "t.yaml:3:8: start: (0, 0) is inside obstacles[0]",
+		},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.file, []byte(tt.data))
+		var perr *Error
+		if !errors.As(err, &perr) {
+			t.Errorf("%s: Parse error = %v, want an *Error", tt.name, err)
+			continue
+		}
+		if got := err.Error(); got != tt.want {
+			t.Errorf("%s: Parse error =\n\t%s\nwant\n\t%s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseOrientedBoxAngle(t *testing.T) {
+	data := head + "obstacles:\n  - type: oriented_box\n    center: [500, 500]\n" +
+		"    width: 400\n    height: 40\n    angle: 45\n"
+	s, err := Parse("t.yaml", []byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	box, ok := s.Obstacles[0].(*rrt.OrientedBox)
+	if !ok {
+		t.Fatalf("obstacles[0] is %T, want *rrt.OrientedBox", s.Obstacles[0])
+	}
+	// 文件中的角度是度数，OrientedBox 使用弧度
+	if want := 45 * math.Pi / 180; math.Abs(box.Angle-want) > 1e-12 {
+		t.Errorf("angle: 45 decodes to %v, want %v", box.Angle, want)
+	}
+}
@@ -0,0 +1,196 @@
+// Package scenario loads planning problems from JSON or YAML files.
+//
+// A scenario describes the workspace bounds, the start and goal, the
+// obstacles and the planner settings:
+//
+//	name: warehouse
+//	bounds: {min: [0, 0], max: [1000, 1000]}
+//	start: [0, 0]
+//	goal: [999, 999]
+//	inflation: 10
+//	obstacles:
+//	  - {type: rect, x: 400, y: 400, width: 200, height: 200}
+//	  - {type: circle, center: [300, 300], radius: 120}
+//	  - {type: oriented_box, center: [650, 350], width: 400, height: 40, angle: 45}
+//	  - {type: polygon, vertices: [[300, 600], [600, 600], [600, 900]]}
+//	  - {type: circle, center: [800, 150], radius: 40, motion: {velocity: [-2, 1]}}
+//	planner:
+//	  algorithm: rrtstar
+//	  steering: apf
+//	  step: 20
+//	  bias: 20
+//	  goal_prob: 0.3
+//	  max_nodes: 5000
+//	  max_speed: 10
+//	  max_wait: 20
+//	  out_of_bounds: clamp
+//	  wall_repulsion: true
+//	  anytime: true
+//	  time_budget: 200ms
+//	  apf: {kp: 1, krep: 0.5, p0: 50, schedule: "iteration:1000", speed: 5}
+//
+// Angles are given in degrees. Only bounds, start and goal are required.
+// Any obstacle may carry a motion, either a constant velocity or a list of
+// waypoints {t, offset} it is interpolated between. The spacetime
+// algorithm plans around them at max_speed, the other algorithms treat them
+// as static at time 0. max_nodes: 0 lifts the iteration cap and is only
+// allowed together with a time_budget.
+package scenario
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bz-2021/rrt_star/rrt"
+)
+
+// Scenario is a planning problem read from a file.
+type Scenario struct {
+	Name      string
+	Bounds    Bounds
+	Start     rrt.Point
+	Goal      rrt.Point
+	Inflation float64 // 障碍物的膨胀半径
+	Obstacles []rrt.Obstacle
+	Planner   Planner
+}
+
+// Bounds is the rectangular workspace.
+type Bounds struct {
+	Min, Max rrt.Point
+}
+
+// Planner holds the planner settings of a scenario.
+type Planner struct {
+	Algorithm     string        // 规划算法，见 rrt.ParseMode
+	Steering      string        // 扩展策略：straight、apf 或 improved-apf
+	Step          float64       // 步长
+	Bias          float64       // 到达目标的误差范围
+	GoalProb      float64       // 目标偏向概率
+	MaxNodes      int           // 最大迭代次数
+	Gamma         float64       // RRT* 邻域半径常数，为 0 时自动计算
+	OutOfBounds   string        // 新节点超出地图时的处理方式：reject 或 clamp
+	WallRepulsion bool          // APF 是否把地图边界当作墙
+	MaxSpeed      float64       // spacetime 模式下的最大速度
+	MaxWait       float64       // spacetime 模式下在节点处最多等待的时间
+	Anytime       bool          // 找到路径后继续改进，直到迭代次数或时间预算用尽
+	TimeBudget    time.Duration // 规划的时间预算，为 0 时不限制
+	APF           APF
+}
+
+// APF holds the gains of the potential field steering strategies.
+type APF struct {
+	Kp       float64 // 引力增益系数
+	Krep     float64 // 斥力增益系数
+	P0       float64 // 斥力作用范围
+	Speed    float64 // improved-apf 中机器人的名义速度，用于估计移动障碍物的位置
+	Schedule string  // improved-apf 的斥力范围调度，见 ParseSchedule
+}
+
+// DefaultPlanner returns the planner settings used for fields a scenario
+// leaves out.
+func DefaultPlanner() Planner {
+	return Planner{
+		Algorithm:   rrt.ModeRRT.String(),
+		Steering:    "straight",
+		Step:        20,
+		Bias:        20,
+		GoalProb:    0.3,
+		MaxNodes:    5000,
+		MaxSpeed:    1,
+		OutOfBounds: rrt.BoundsReject.String(),
+		APF: APF{
+			Kp:   1,
+			Krep: 0.5,
+			P0:   50,
+		},
+	}
+}
+
+// Load reads a scenario file. Files ending in .json are parsed as JSON,
+// anything else as YAML.
+func Load(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(path, data)
+}
+
+// NewRRT builds a ready-to-plan RRT from the scenario.
+func (s *Scenario) NewRRT() (*rrt.RRT, error) {
+	mode, err := rrt.ParseMode(s.Planner.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	outOfBounds, err := rrt.ParseBoundsPolicy(s.Planner.OutOfBounds)
+	if err != nil {
+		return nil, err
+	}
+	extender, err := NewExtender(s.Planner.Steering, s.Planner.APF)
+	if err != nil {
+		return nil, err
+	}
+
+	r := rrt.NewRRT(s.Start, s.Goal, s.Planner.Step, s.Planner.Bias, s.Planner.MaxNodes,
+		s.Bounds.Max.X, s.Bounds.Max.Y, s.Obstacles, s.Inflation)
+	r.Mode = mode
+	r.XMin, r.YMin = s.Bounds.Min.X, s.Bounds.Min.Y
+	r.OutOfBounds = outOfBounds
+	r.WallRepulsion = s.Planner.WallRepulsion
+	r.Extender = extender
+	r.GoalProb = s.Planner.GoalProb
+	r.Gamma = s.Planner.Gamma
+	r.MaxSpeed = s.Planner.MaxSpeed
+	r.MaxWait = s.Planner.MaxWait
+	r.Anytime = s.Planner.Anytime
+	r.TimeBudget = s.Planner.TimeBudget
+	return r, nil
+}
+
+// NewExtender returns the steering strategy with the given name.
+func NewExtender(name string, apf APF) (rrt.Extender, error) {
+	switch name {
+	case "straight":
+		return rrt.StraightLine{}, nil
+	case "apf":
+		return rrt.NewAPF(apf.Kp, apf.Krep, apf.P0), nil
+	case "improved-apf":
+		a := rrt.NewImprovedAPF(apf.Kp, apf.Krep, apf.P0)
+		a.Speed = apf.Speed
+		if apf.Schedule != "" {
+			schedule, err := ParseSchedule(apf.Schedule)
+			if err != nil {
+				return nil, err
+			}
+			a.Schedule = schedule
+		}
+		return a, nil
+	}
+	return nil, fmt.Errorf("unknown steering %q", name)
+}
+
+// ParseSchedule parses a repulsion range schedule written as kind:value,
+// where kind is iteration, tree_size or goal_distance. For the first two
+// value is the period, for goal_distance the distance at which the range
+// starts to shrink.
+func ParseSchedule(spec string) (rrt.RangeSchedule, error) {
+	kind, value, ok := strings.Cut(spec, ":")
+	v, err := strconv.ParseFloat(value, 64)
+	if !ok || err != nil || v <= 0 {
+		return nil, fmt.Errorf("invalid schedule %q (want kind:value with a positive value)", spec)
+	}
+
+	switch kind {
+	case "iteration":
+		return rrt.IterationSchedule(v), nil
+	case "tree_size":
+		return rrt.TreeSizeSchedule(v), nil
+	case "goal_distance":
+		return rrt.GoalDistanceSchedule(v), nil
+	}
+	return nil, fmt.Errorf("unknown schedule %q (want iteration, tree_size or goal_distance)", kind)
+}
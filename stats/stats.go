@@ -0,0 +1,142 @@
+// Package stats summarizes the results of repeated planning runs.
+package stats
+
+import (
+	"math"
+	"sort"
+
+	"github.com/bz-2021/rrt_star/rrt"
+)
+
+// Summary describes a sample of values.
+type Summary struct {
+	N      int
+	Mean   float64
+	Std    float64 // 样本标准差（n-1）
+	Median float64
+	Min    float64
+	Max    float64
+	CILow  float64 // 均值的 95% 置信区间下限
+	CIHigh float64 // 均值的 95% 置信区间上限
+}
+
+// Summarize computes the summary of xs. An empty sample gives a zero
+// Summary.
+func Summarize(xs []float64) Summary {
+	n := len(xs)
+	if n == 0 {
+		return Summary{}
+	}
+
+	sorted := append([]float64(nil), xs...)
+	sort.Float64s(sorted)
+
+	s := Summary{
+		N:    n,
+		Mean: Mean(xs),
+		Min:  sorted[0],
+		Max:  sorted[n-1],
+	}
+	if n%2 == 1 {
+		s.Median = sorted[n/2]
+	} else {
+		s.Median = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+	s.Std = math.Sqrt(Variance(xs))
+	s.CILow, s.CIHigh = MeanCI(xs, 0.95)
+	return s
+}
+
+// Mean returns the arithmetic mean of xs, or 0 for an empty sample.
+func Mean(xs []float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, x := range xs {
+		sum += x
+	}
+	return sum / float64(len(xs))
+}
+
+// Variance returns the sample variance of xs, or 0 for fewer than two
+// values.
+func Variance(xs []float64) float64 {
+	n := len(xs)
+	if n < 2 {
+		return 0
+	}
+	m := Mean(xs)
+	ss := 0.0
+	for _, x := range xs {
+		ss += (x - m) * (x - m)
+	}
+	return ss / float64(n-1)
+}
+
+// Quantile returns the q-quantile of xs, interpolating linearly between
+// the closest ranks, or NaN for an empty sample.
+func Quantile(xs []float64, q float64) float64 {
+	n := len(xs)
+	if n == 0 {
+		return math.NaN()
+	}
+	sorted := append([]float64(nil), xs...)
+	sort.Float64s(sorted)
+
+	h := math.Max(0, math.Min(1, q)) * float64(n-1)
+	lo := int(math.Floor(h))
+	if lo == n-1 {
+		return sorted[lo]
+	}
+	return sorted[lo] + (h-float64(lo))*(sorted[lo+1]-sorted[lo])
+}
+
+// Aggregate summarizes a set of planning runs. Path statistics only cover
+// the successful runs, everything else covers all runs.
+type Aggregate struct {
+	Runs        int
+	Successes   int
+	SuccessRate float64 // 成功率，0 到 1
+
+	Cost            Summary // 路径长度
+	Waypoints       Summary // 路径上的点数
+	Iterations      Summary
+	Nodes           Summary // 树中的节点数
+	CollisionChecks Summary
+	Escapes         Summary
+	WallTime        Summary // 单位为秒
+}
+
+// Collect aggregates the results of repeated runs.
+func Collect(results []rrt.Result) Aggregate {
+	var cost, waypoints, iterations, nodes, checks, escapes, wallTime []float64
+	for _, r := range results {
+		if r.Success {
+			cost = append(cost, r.Cost)
+			waypoints = append(waypoints, float64(r.Waypoints))
+		}
+		iterations = append(iterations, float64(r.Iterations))
+		nodes = append(nodes, float64(r.Nodes))
+		checks = append(checks, float64(r.CollisionChecks))
+		escapes = append(escapes, float64(r.Escapes))
+		wallTime = append(wallTime, r.WallTime.Seconds())
+	}
+
+	a := Aggregate{
+		Runs:      len(results),
+		Successes: len(cost),
+
+		Cost:            Summarize(cost),
+		Waypoints:       Summarize(waypoints),
+		Iterations:      Summarize(iterations),
+		Nodes:           Summarize(nodes),
+		CollisionChecks: Summarize(checks),
+		Escapes:         Summarize(escapes),
+		WallTime:        Summarize(wallTime),
+	}
+	if a.Runs > 0 {
+		a.SuccessRate = float64(a.Successes) / float64(a.Runs)
+	}
+	return a
+}
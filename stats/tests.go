@@ -0,0 +1,157 @@
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+// TTest is the result of Welch's two-sample t-test.
+type TTest struct {
+	T  float64 // t 统计量，正值表示第一组均值更大
+	DF float64 // Welch–Satterthwaite 自由度
+	P  float64 // 双侧 p 值
+}
+
+// WelchTTest tests whether a and b have the same mean without assuming
+// equal variances. Both samples need at least two values; otherwise P is
+// NaN.
+func WelchTTest(a, b []float64) TTest {
+	na, nb := float64(len(a)), float64(len(b))
+	if na < 2 || nb < 2 {
+		return TTest{T: math.NaN(), DF: math.NaN(), P: math.NaN()}
+	}
+
+	va, vb := Variance(a)/na, Variance(b)/nb
+	diff := Mean(a) - Mean(b)
+	if va+vb == 0 {
+		// 两组都没有方差：均值相同则无差异，否则差异确定
+		if diff == 0 {
+			return TTest{T: 0, DF: na + nb - 2, P: 1}
+		}
+		return TTest{T: math.Copysign(math.Inf(1), diff), DF: na + nb - 2, P: 0}
+	}
+
+	t := diff / math.Sqrt(va+vb)
+	df := (va + vb) * (va + vb) / (va*va/(na-1) + vb*vb/(nb-1))
+	return TTest{T: t, DF: df, P: 2 * studentTCDF(-math.Abs(t), df)}
+}
+
+// UTest is the result of the Mann–Whitney U test.
+type UTest struct {
+	U float64 // 第一组的 U 统计量
+	Z float64 // 正态近似的 z 值（含连续性校正）
+	P float64 // 双侧 p 值
+}
+
+// MannWhitneyU tests whether values of a tend to be larger or smaller than
+// values of b. The p-value uses the normal approximation with tie and
+// continuity correction, which is accurate for the sample sizes of a
+// benchmark (about 10 or more per group). Empty samples give a NaN P.
+func MannWhitneyU(a, b []float64) UTest {
+	na, nb := float64(len(a)), float64(len(b))
+	if na == 0 || nb == 0 {
+		return UTest{U: math.NaN(), Z: math.NaN(), P: math.NaN()}
+	}
+
+	// 合并排序后求秩，并列的值取平均秩
+	type value struct {
+		x     float64
+		first bool
+	}
+	all := make([]value, 0, len(a)+len(b))
+	for _, x := range a {
+		all = append(all, value{x, true})
+	}
+	for _, x := range b {
+		all = append(all, value{x, false})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].x < all[j].x })
+
+	rankSum, ties := 0.0, 0.0
+	for i := 0; i < len(all); {
+		j := i
+		for j < len(all) && all[j].x == all[i].x {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if all[k].first {
+				rankSum += rank
+			}
+		}
+		t := float64(j - i)
+		ties += t*t*t - t
+		i = j
+	}
+
+	u := rankSum - na*(na+1)/2
+	n := na + nb
+	mean := na * nb / 2
+	variance := na * nb / 12 * ((n + 1) - ties/(n*(n-1)))
+	if variance <= 0 {
+		return UTest{U: u, Z: 0, P: 1}
+	}
+
+	d := u - mean
+	d = math.Copysign(math.Max(math.Abs(d)-0.5, 0), d)
+	z := d / math.Sqrt(variance)
+	return UTest{U: u, Z: z, P: math.Min(1, 2*normalCDF(-math.Abs(z)))}
+}
+
+// MeanCI returns the confidence interval of the mean of xs at the given
+// level (e.g. 0.95) from Student's t distribution. With fewer than two
+// values both bounds are the mean.
+func MeanCI(xs []float64, level float64) (float64, float64) {
+	m := Mean(xs)
+	n := float64(len(xs))
+	if n < 2 {
+		return m, m
+	}
+	half := studentTQuantile(1-(1-level)/2, n-1) * math.Sqrt(Variance(xs)/n)
+	return m - half, m + half
+}
+
+// CohensD returns the standardized mean difference of a and b using the
+// pooled standard deviation. It is NaN if either sample has fewer than two
+// values or both have no spread.
+func CohensD(a, b []float64) float64 {
+	na, nb := float64(len(a)), float64(len(b))
+	if na < 2 || nb < 2 {
+		return math.NaN()
+	}
+	pooled := ((na-1)*Variance(a) + (nb-1)*Variance(b)) / (na + nb - 2)
+	if pooled == 0 {
+		return math.NaN()
+	}
+	return (Mean(a) - Mean(b)) / math.Sqrt(pooled)
+}
+
+// CliffsDelta returns P(a > b) - P(a < b) over all pairs of values, an
+// effect size between -1 and 1 that matches the Mann–Whitney U test.
+func CliffsDelta(a, b []float64) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return math.NaN()
+	}
+	u := MannWhitneyU(a, b).U
+	return 2*u/float64(len(a)*len(b)) - 1
+}
+
+// TwoSample collects the tests and effect sizes comparing two samples.
+type TwoSample struct {
+	MeanDiff    float64 // 第一组均值减第二组均值
+	Welch       TTest
+	MannWhitney UTest
+	CohensD     float64
+	CliffsDelta float64
+}
+
+// Compare runs all two-sample tests on a and b.
+func Compare(a, b []float64) TwoSample {
+	return TwoSample{
+		MeanDiff:    Mean(a) - Mean(b),
+		Welch:       WelchTTest(a, b),
+		MannWhitney: MannWhitneyU(a, b),
+		CohensD:     CohensD(a, b),
+		CliffsDelta: CliffsDelta(a, b),
+	}
+}
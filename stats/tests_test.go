@@ -0,0 +1,124 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+// mtcars$mpg split by transmission (am = 0 and am = 1), from R's datasets
+// package. The sample contains ties.
+var (
+	mpgAutomatic = []float64{21.4, 18.7, 18.1, 14.3, 24.4, 22.8, 19.2, 17.8, 16.4, 17.3,
+		15.2, 10.4, 10.4, 14.7, 21.5, 15.5, 15.2, 13.3, 19.2}
+	mpgManual = []float64{21.0, 21.0, 22.8, 32.4, 30.4, 33.9, 27.3, 26.0, 30.4, 15.8,
+		19.7, 15.0, 21.4}
+)
+
+func near(got, want, tol float64) bool {
+	return math.Abs(got-want) <= tol
+}
+
+func TestWelchTTest(t *testing.T) {
+	tests := []struct {
+		name      string
+		a, b      []float64
+		t, df, p  float64
+		tolT, tol float64
+	}{
+		// Welch's t-test, Wikipedia, example 1
+		{
+			name: "wikipedia",
+			a:    []float64{27.5, 21.0, 19.0, 23.6, 17.0, 17.9, 16.9, 20.1, 21.9, 22.6, 23.1, 19.6, 19.0, 21.7, 21.4},
+			b:    []float64{27.1, 22.0, 20.8, 23.4, 23.4, 23.5, 25.8, 22.0, 24.8, 20.2, 21.9, 22.1, 22.9, 20.5, 24.4},
+			t:    -2.455, df: 24.99, p: 0.0214,
+			tolT: 0.001, tol: 0.0001,
+		},
+		// R: t.test(mpg ~ am, data = mtcars)
+		{
+			name: "mtcars",
+			a:    mpgAutomatic,
+			b:    mpgManual,
+			t:    -3.7671, df: 18.332, p: 0.001374,
+			tolT: 0.0001, tol: 0.000001,
+		},
+	}
+	for _, tt := range tests {
+		got := WelchTTest(tt.a, tt.b)
+		if !near(got.T, tt.t, tt.tolT) || !near(got.DF, tt.df, 0.01) || !near(got.P, tt.p, tt.tol) {
+			t.Errorf("%s: WelchTTest = %+v, want t=%v df=%v p=%v", tt.name, got, tt.t, tt.df, tt.p)
+		}
+	}
+
+	if got := WelchTTest([]float64{1}, []float64{1, 2}); !math.IsNaN(got.P) {
+		t.Errorf("WelchTTest with one value: P = %v, want NaN", got.P)
+	}
+	if got := WelchTTest([]float64{2, 2}, []float64{2, 2, 2}); got.P != 1 {
+		t.Errorf("WelchTTest of equal constants: P = %v, want 1", got.P)
+	}
+}
+
+func TestMannWhitneyU(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []float64
+		u, p float64
+	}{
+		// R: wilcox.test(mpg ~ am, data = mtcars), normal approximation
+		// with tie and continuity correction
+		{"mtcars", mpgAutomatic, mpgManual, 42, 0.001871},
+		{"mtcars swapped", mpgManual, mpgAutomatic, 205, 0.001871},
+		// 手算：秩和 22.5，并列组大小 2、4、2、2，方差 3*(13-78/132)，
+		// z = (1.5-18+0.5)/sqrt(37.2273) = -2.6223
+		{"small with ties", []float64{1, 2, 2, 3, 3, 3}, []float64{3, 4, 4, 5, 5, 6}, 1.5, 0.008733},
+	}
+	for _, tt := range tests {
+		got := MannWhitneyU(tt.a, tt.b)
+		if got.U != tt.u || !near(got.P, tt.p, 0.000001) {
+			t.Errorf("%s: MannWhitneyU = %+v, want U=%v p=%v", tt.name, got, tt.u, tt.p)
+		}
+	}
+}
+
+func TestMeanCI(t *testing.T) {
+	xs := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	// R: t.test(1:10)$conf.int
+	if lo, hi := MeanCI(xs, 0.95); !near(lo, 3.334149, 1e-6) || !near(hi, 7.665851, 1e-6) {
+		t.Errorf("MeanCI(1..10, 0.95) = %v..%v, want 3.334149..7.665851", lo, hi)
+	}
+	if s := Summarize(xs); !near(s.CILow, 3.334149, 1e-6) || !near(s.CIHigh, 7.665851, 1e-6) {
+		t.Errorf("Summarize(1..10) CI = %v..%v, want 3.334149..7.665851", s.CILow, s.CIHigh)
+	}
+}
+
+func TestStudentT(t *testing.T) {
+	// R: qt(p, df)
+	for _, tt := range []struct{ p, df, want float64 }{
+		{0.975, 1, 12.706205},
+		{0.975, 9, 2.262157},
+		{0.995, 30, 2.749996},
+		{0.9, 1000, 1.282399},
+	} {
+		if got := studentTQuantile(tt.p, tt.df); !near(got, tt.want, 1e-5) {
+			t.Errorf("studentTQuantile(%v, %v) = %v, want %v", tt.p, tt.df, got, tt.want)
+		}
+		if got := studentTCDF(tt.want, tt.df); !near(got, tt.p, 1e-7) {
+			t.Errorf("studentTCDF(%v, %v) = %v, want %v", tt.want, tt.df, got, tt.p)
+		}
+	}
+
+	// I_0.4(2, 3) = P(Binomial(4, 0.4) >= 2)
+	if got := incompleteBeta(2, 3, 0.4); !near(got, 0.5248, 1e-12) {
+		t.Errorf("incompleteBeta(2, 3, 0.4) = %v, want 0.5248", got)
+	}
+}
+
+func TestEffectSizes(t *testing.T) {
+	a, b := []float64{1, 2, 3}, []float64{2, 3, 4}
+	if got := CohensD(a, b); !near(got, -1, 1e-12) {
+		t.Errorf("CohensD = %v, want -1", got)
+	}
+	// 1 对 a > b，6 对 a < b，2 对相等
+	if got := CliffsDelta(a, b); !near(got, -5.0/9, 1e-12) {
+		t.Errorf("CliffsDelta = %v, want %v", got, -5.0/9)
+	}
+}